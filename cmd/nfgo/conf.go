@@ -0,0 +1,135 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/nerrors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// keyringConfig - provides the keyring of the -key flags to decrypt the secrets of the config.
+type keyringConfig struct {
+	*nconf.Config
+	keyring *nconf.Keyring
+}
+
+func (c *keyringConfig) SetConfig(config *nconf.Config) {
+	c.Config = config
+}
+
+func (c *keyringConfig) SecretKeyring() *nconf.Keyring {
+	return c.keyring
+}
+
+// loadConfig - the secrets are decrypted only if decrypt, otherwise they are kept as SECRET(...) without a key.
+func (c *cli) loadConfig(name string, args []string, decrypt bool) (*nconf.Config, error) {
+	fs := c.newFlagSet(name)
+	confPath := fs.String("f", "app.yaml", "the config file")
+	profile := fs.String("p", "", "the profile, default is NFGO_APP_PROFILE or app.profile of the config file")
+	var keys keysFlag
+	if decrypt {
+		fs.Var(&keys, "key", "the secret key in the form of [kid:]key to decrypt the secrets, repeatable")
+	}
+	if err := c.parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	var keyring *nconf.Keyring
+	if decrypt {
+		var err error
+		if keyring, err = c.keyring(keys, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return nconf.NewConfigFromSources(&keyringConfig{keyring: keyring},
+		nconf.NewFileSource(*confPath, nconf.ProfileOption(*profile)),
+		nconf.NewEnvSource(),
+	)
+}
+
+func (c *cli) confValidate(args []string) error {
+	_, err := c.loadConfig("conf validate", args, true)
+	if err != nil {
+		if errs := nerrors.Errors(err); len(errs) > 1 {
+			for _, e := range errs {
+				fmt.Fprintln(c.stderr, "  -", e)
+			}
+			return nerrors.Errorf("%d problems in the config", len(errs))
+		}
+		return err
+	}
+	fmt.Fprintln(c.stdout, "the config is valid")
+	return nil
+}
+
+func (c *cli) confDump(args []string) error {
+	// the secrets are masked anyway, so they are not decrypted
+	config, err := c.loadConfig("conf dump", args, false)
+	if err != nil {
+		return err
+	}
+	nconf.RedactSecrets(config)
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	var tree yaml.MapSlice
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+	if data, err = yaml.Marshal(pruneNulls(tree)); err != nil {
+		return err
+	}
+	_, err = c.stdout.Write(data)
+	return err
+}
+
+func (c *cli) confSchema(args []string) error {
+	fs := c.newFlagSet("conf schema")
+	output := fs.String("o", "", "the file to write the JSON Schema, default is stdout")
+	if err := c.parseFlags(fs, args); err != nil {
+		return err
+	}
+	data, err := nconf.JSONSchema(nil)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if *output != "" {
+		return os.WriteFile(*output, data, 0644)
+	}
+	_, err = c.stdout.Write(data)
+	return err
+}
+
+// pruneNulls - removes the null sections which are not configured.
+func pruneNulls(tree yaml.MapSlice) yaml.MapSlice {
+	pruned := make(yaml.MapSlice, 0, len(tree))
+	for _, item := range tree {
+		switch v := item.Value.(type) {
+		case nil:
+			continue
+		case yaml.MapSlice:
+			item.Value = pruneNulls(v)
+		}
+		pruned = append(pruned, item)
+	}
+	return pruned
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command nfgo - the tool to manage the secrets and the config files of the nfgo applications.
+//
+//	nfgo secret gen-key [-bits 256]
+//	nfgo secret encrypt [-key [kid:]key]... [plain text]
+//	nfgo secret decrypt [-key [kid:]key]... [SECRET(...)]
+//	nfgo conf validate [-f app.yaml] [-p profile] [-key [kid:]key]...
+//	nfgo conf dump [-f app.yaml] [-p profile]
+//	nfgo conf schema [-o app.schema.json]
+//
+// The first key is the current one which encrypts, the keys are read from the env var NFGOCTL_SECRET_KEYS
+// separated by commas if there is no -key flag. The text is read from stdin if it is not in the args.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const usage = `Usage:
+  nfgo secret gen-key [-bits 256]
+  nfgo secret encrypt [-key [kid:]key]... [plain text]
+  nfgo secret decrypt [-key [kid:]key]... [SECRET(...)]
+  nfgo conf validate [-f app.yaml] [-p profile] [-key [kid:]key]...
+  nfgo conf dump [-f app.yaml] [-p profile]
+  nfgo conf schema [-o app.schema.json]
+`
+
+var errUsage = errors.New("usage")
+
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+}
+
+func main() {
+	c := &cli{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+	}
+	os.Exit(c.run(os.Args[1:]))
+}
+
+func (c *cli) run(args []string) int {
+	if len(args) < 2 {
+		fmt.Fprint(c.stderr, usage)
+		return 2
+	}
+	commands := map[string]func(args []string) error{
+		"secret gen-key": c.secretGenKey,
+		"secret encrypt": c.secretEncrypt,
+		"secret decrypt": c.secretDecrypt,
+		"conf validate":  c.confValidate,
+		"conf dump":      c.confDump,
+		"conf schema":    c.confSchema,
+	}
+	cmd, ok := commands[args[0]+" "+args[1]]
+	if !ok {
+		fmt.Fprint(c.stderr, usage)
+		return 2
+	}
+
+	err := cmd(args[2:])
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	}
+	fmt.Fprintln(c.stderr, "nfgo:", err)
+	return 1
+}
+
+func (c *cli) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("nfgo "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	return fs
+}
+
+func (c *cli) parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+// textArg - returns the args joined, or the first line of stdin if there is no arg.
+func (c *cli) textArg(fs *flag.FlagSet) (string, error) {
+	if fs.NArg() > 0 {
+		return strings.Join(fs.Args(), " "), nil
+	}
+	line, err := bufio.NewReader(c.stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCLI(stdin string) (*cli, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	return &cli{
+		stdin:  strings.NewReader(stdin),
+		stdout: stdout,
+		stderr: stderr,
+		getenv: func(string) string { return "" },
+	}, stdout, stderr
+}
+
+func TestSecretCommands(t *testing.T) {
+	a := assert.New(t)
+	c, stdout, _ := newTestCLI("")
+	a.Equal(0, c.run([]string{"secret", "gen-key"}))
+	key := strings.TrimSpace(stdout.String())
+	a.Len(key, 44)
+
+	c, stdout, _ = newTestCLI("hello world\n")
+	a.Equal(0, c.run([]string{"secret", "encrypt", "-key", "k1:" + key}))
+	secret := strings.TrimSpace(stdout.String())
+	a.True(strings.HasPrefix(secret, "SECRET(k1:"))
+
+	c, stdout, _ = newTestCLI("")
+	c.getenv = func(string) string { return "k2:" + key + ",k1:" + key }
+	a.Equal(0, c.run([]string{"secret", "decrypt", secret}))
+	a.Equal("hello world\n", stdout.String())
+
+	c, _, stderr := newTestCLI("")
+	a.Equal(1, c.run([]string{"secret", "encrypt", "text"}))
+	a.Contains(stderr.String(), "no secret key")
+
+	c, _, _ = newTestCLI("")
+	a.Equal(2, c.run([]string{"secret", "rotate"}))
+}
+
+func TestConfCommands(t *testing.T) {
+	a := assert.New(t)
+	key := "Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk="
+	dir := t.TempDir()
+	confPath := filepath.Join(dir, "app.yaml")
+	a.Nil(os.WriteFile(confPath, []byte(`
+app:
+  name: foo
+db:
+  host: 127.0.0.1
+  port: 3306
+  database: test
+  username: root
+  password: SECRET(gYVa2GgdDYbR6R4AlvB2Tcwi/z9yT20tkiADAsd4yxO3flA1xV0a)
+`), 0644))
+	a.Nil(os.WriteFile(filepath.Join(dir, "app-prod.yaml"), []byte("web:\n  port: -1\n"), 0644))
+
+	c, stdout, _ := newTestCLI("")
+	a.Equal(0, c.run([]string{"conf", "validate", "-f", confPath, "-key", key}))
+	a.Contains(stdout.String(), "valid")
+
+	c, _, stderr := newTestCLI("")
+	a.Equal(1, c.run([]string{"conf", "validate", "-f", confPath, "-p", "prod", "-key", key}))
+	a.Contains(stderr.String(), "web.port -1 is out of range")
+
+	c, stdout, _ = newTestCLI("")
+	a.Equal(0, c.run([]string{"conf", "dump", "-f", confPath}))
+	a.Contains(stdout.String(), "password: '******'")
+	a.Contains(stdout.String(), "name: foo")
+	a.NotContains(stdout.String(), "hello world")
+	a.NotContains(stdout.String(), "null")
+
+	c, stdout, _ = newTestCLI("")
+	a.Equal(0, c.run([]string{"conf", "schema"}))
+	a.Contains(stdout.String(), `"$schema"`)
+}
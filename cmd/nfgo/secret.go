@@ -0,0 +1,119 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/nf-go/nfgo/nutil/ncrypto"
+)
+
+// envSecretKeys - the keys used if there is no -key flag, e.g. k2:base64key,k1:base64key.
+// It is out of the NFGO_ prefix of the config overrides, see nconf.NewEnvSource.
+const envSecretKeys = "NFGOCTL_SECRET_KEYS"
+
+// keysFlag - the repeatable -key flag in the form of [kid:]key.
+type keysFlag []string
+
+func (f *keysFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *keysFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func (c *cli) keyring(keys keysFlag, required bool) (*nconf.Keyring, error) {
+	if len(keys) == 0 {
+		if env := c.getenv(envSecretKeys); env != "" {
+			keys = strings.Split(env, ",")
+		}
+	}
+	if len(keys) == 0 {
+		if required {
+			return nil, nerrors.Errorf("no secret key, use -key or %s", envSecretKeys)
+		}
+		return nil, nil
+	}
+
+	keyMap := make(map[string]string, len(keys))
+	var currentKeyID string
+	for i, k := range keys {
+		keyID, key, ok := strings.Cut(strings.TrimSpace(k), ":")
+		if !ok {
+			keyID, key = "", keyID
+		}
+		if _, ok := keyMap[keyID]; ok {
+			return nil, nerrors.Errorf("secret key %q is duplicated", keyID)
+		}
+		keyMap[keyID] = key
+		if i == 0 {
+			currentKeyID = keyID
+		}
+	}
+	return nconf.NewKeyring(currentKeyID, keyMap)
+}
+
+func (c *cli) secretGenKey(args []string) error {
+	fs := c.newFlagSet("secret gen-key")
+	bits := fs.Int("bits", 256, "the size of the aes key, 128, 192 or 256")
+	if err := c.parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *bits != 128 && *bits != 192 && *bits != 256 {
+		return nerrors.Errorf("-bits %d is not one of 128, 192, 256", *bits)
+	}
+	key, err := ncrypto.NewAESKeyString(*bits)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.stdout, key)
+	return nil
+}
+
+func (c *cli) secretEncrypt(args []string) error {
+	return c.secretCrypt("secret encrypt", args, (*nconf.Keyring).Encrypt)
+}
+
+func (c *cli) secretDecrypt(args []string) error {
+	return c.secretCrypt("secret decrypt", args, (*nconf.Keyring).Decrypt)
+}
+
+func (c *cli) secretCrypt(name string, args []string, crypt func(k *nconf.Keyring, text string) (string, error)) error {
+	fs := c.newFlagSet(name)
+	var keys keysFlag
+	fs.Var(&keys, "key", "the secret key in the form of [kid:]key, repeatable, the first one encrypts")
+	if err := c.parseFlags(fs, args); err != nil {
+		return err
+	}
+	keyring, err := c.keyring(keys, true)
+	if err != nil {
+		return err
+	}
+	text, err := c.textArg(fs)
+	if err != nil {
+		return err
+	}
+	result, err := crypt(keyring, text)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(c.stdout, result)
+	return nil
+}
@@ -30,6 +30,16 @@ type Config struct {
 	RPC        *RPCConfig     `yaml:"rpc"`
 	CronConfig *CronConfig    `yaml:"cron"`
 	Metrics    *MetricsConfig `yaml:"metrics"`
+	Features   FeaturesConfig `yaml:"features"`
+	Trace      *TraceConfig   `yaml:"trace"`
+
+	// keyring - the keyring which decrypted the secrets of the config, see SecretKeyringConfig
+	keyring *Keyring
+}
+
+// Keyring - the keyring of the secrets of the config, nil if the custom config provides none.
+func (conf *Config) Keyring() *Keyring {
+	return conf.keyring
 }
 
 // AppConfig -
@@ -47,42 +57,147 @@ func (c *AppConfig) IsProfileLocal() bool {
 	return c.Profile == "" || c.Profile == nconst.ProfileLocal
 }
 
-// ExtConfig -
-type ExtConfig map[string]interface{}
+// LogConfig -
+type LogConfig struct {
+	Level           string `yaml:"level"`
+	Format          string `yaml:"format"`
+	PrettyPrint     bool   `yaml:"prettyPrint"`
+	CallerPrint     bool   `yaml:"callerPrint"`
+	TimestampFormat string `yaml:"timestampFormat"`
+	LogPath         string `yaml:"logPath"`
+	LogFilename     string `yaml:"logFilename"`
+	// Rotation - rotates the log file in {logPath}/{yyyyMM}/{logFilename}, only if logPath is set.
+	Rotation *LogRotationConfig `yaml:"rotation"`
+	// Sinks - the destinations of the logs, they replace logPath and the default stderr if set.
+	Sinks []*LogSinkConfig `yaml:"sinks"`
+	// Levels - the levels of the named loggers such as ndb, the others follow Level.
+	Levels map[string]string `yaml:"levels"`
+	// Redaction - masks the sensitive fields of the logged requests, responses and fields.
+	Redaction []*LogRedactionRule `yaml:"redaction"`
+	// Sampling - samples the entries with the same level and message every second, see LogSamplingConfig.
+	Sampling *LogSamplingConfig `yaml:"sampling"`
+	// TraceDebug - keeps all the entries of the traces flagged as debug by the MDC at debug level without sampling,
+	// the flag is bound from the X-Debug header by the web and rpc servers.
+	TraceDebug bool `yaml:"traceDebug"`
+	// TraceDebugToken - the X-Debug header flags the trace as debug only if it is the token, so that only the trusted
+	// ingress which knows the token can turn on the debug trace. It is required by TraceDebug.
+	TraceDebugToken string `yaml:"traceDebugToken" secret:"true"`
+	// SlogDefault - installs nlog.NewSlogHandler as the default of log/slog, which the standard log writes to as well.
+	SlogDefault bool `yaml:"slogDefault"`
+	// Async - writes the logs to the sinks asynchronously, see LogAsyncConfig.
+	Async *LogAsyncConfig `yaml:"async"`
+	// ErrorStack - renders the chain and the stack of the logged errors as fields, see LogErrorStackConfig.
+	ErrorStack *LogErrorStackConfig `yaml:"errorStack"`
+}
 
-// StrVal -
-func (e ExtConfig) StrVal(key string) string {
-	if val, ok := e[key].(string); ok {
-		return val
-	}
-	return ""
+// the overflow policies of the async logging
+const (
+	// LogOverflowBlock - the logging blocks until the queue has room, it is the default policy.
+	LogOverflowBlock = "block"
+	// LogOverflowDropDebug - the debug entries are dropped if the queue is full, the others block.
+	LogOverflowDropDebug = "dropDebug"
+	// LogOverflowDropAll - all the entries are dropped if the queue is full.
+	LogOverflowDropAll = "dropAll"
+)
+
+// LogAsyncConfig - the entries are encoded by the logging goroutines, then queued and written by a background goroutine,
+// which flushes the buffered entries when the queue is empty and every FlushInterval. nlog.Sync flushes the queue.
+type LogAsyncConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// QueueSize - the max number of the queued entries, it defaults to 8192.
+	QueueSize int `yaml:"queueSize"`
+	// Overflow - block, dropDebug or dropAll.
+	Overflow string `yaml:"overflow"`
+	// FlushInterval - it defaults to 1s.
+	FlushInterval time.Duration `yaml:"flushInterval"`
 }
 
-// IntVal -
-func (e ExtConfig) IntVal(key string) int {
-	if val, ok := e[key].(int); ok {
-		return val
-	}
-	return 0
+// LogErrorStackConfig - the error of WithError in the entries at Level or above is rendered as error (the message),
+// error.chain (the messages of the wrapped errors) and error.stack (the innermost stack recorded by nerrors)
+// instead of error and errorVerbose. It is enabled by default.
+type LogErrorStackConfig struct {
+	Disabled bool `yaml:"disabled"`
+	// Level - the min level of the entries, it defaults to error.
+	Level string `yaml:"level"`
+	// MaxFrames - the max number of the frames in error.stack, it defaults to 32.
+	MaxFrames int `yaml:"maxFrames"`
 }
 
-// BoolVal -
-func (e ExtConfig) BoolVal(key string) bool {
-	if val, ok := e[key].(bool); ok {
-		return val
-	}
-	return false
+// LogSamplingConfig - logs the first Initial entries with the same level and message every second,
+// then every Thereafter-th of them. Initial and Thereafter default to 100.
+type LogSamplingConfig struct {
+	// Disabled - keeps all the entries.
+	Disabled   bool `yaml:"disabled"`
+	Initial    int  `yaml:"initial"`
+	Thereafter int  `yaml:"thereafter"`
 }
 
-// LogConfig -
-type LogConfig struct {
+// the strategies of the log redaction
+const (
+	// LogRedactMask - replaces the value with ******, it is the default strategy.
+	LogRedactMask = "mask"
+	// LogRedactPartial - masks the value but keeps its first KeepPrefix and last KeepSuffix chars.
+	LogRedactPartial = "partial"
+	// LogRedactHash - replaces the value with its HMAC-SHA256 keyed by HashKey, so the equal values can still be
+	// correlated but can not be brute-forced back without the key.
+	LogRedactHash = "hash"
+	// LogRedactRemove - removes the field.
+	LogRedactRemove = "remove"
+)
+
+// LogRedactionRule - the fields and how they are redacted.
+type LogRedactionRule struct {
+	// Fields - the field names matched case-insensitively at any depth, such as password or *token,
+	// or the JSON paths such as $.user.idCard or user.*.phone, the arrays are transparent in the paths.
+	Fields []string `yaml:"fields"`
+	// Strategy - mask, partial, hash or remove.
+	Strategy string `yaml:"strategy"`
+	// KeepPrefix, KeepSuffix - the chars kept by the partial strategy, they default to 3 and 4 if both are 0.
+	KeepPrefix int `yaml:"keepPrefix"`
+	KeepSuffix int `yaml:"keepSuffix"`
+	// HashKey - the secret key of the hash strategy, which is required by it. The values are masked instead
+	// if it is not set.
+	HashKey string `yaml:"hashKey" secret:"true"`
+}
+
+// the types of the log sinks
+const (
+	LogSinkStderr = "stderr"
+	LogSinkStdout = "stdout"
+	LogSinkFile   = "file"
+	LogSinkTCP    = "tcp"
+	LogSinkUDP    = "udp"
+)
+
+// LogSinkConfig - a destination of the logs, the empty fields default to the ones of LogConfig.
+type LogSinkConfig struct {
+	// Type - stderr, stdout, file, tcp or udp.
+	Type string `yaml:"type"`
+	// Level - the min level of the sink, it can only be higher than log.level.
 	Level           string `yaml:"level"`
 	Format          string `yaml:"format"`
-	PrettyPrint     bool   `yaml:"prettyPrint"`
-	CallerPrint     bool   `yaml:"callerPrint"`
 	TimestampFormat string `yaml:"timestampFormat"`
-	LogPath         string `yaml:"logPath"`
-	LogFilename     string `yaml:"logFilename"`
+	// LogPath, LogFilename, Rotation - the file of the file sink, see LogConfig.
+	LogPath     string             `yaml:"logPath"`
+	LogFilename string             `yaml:"logFilename"`
+	Rotation    *LogRotationConfig `yaml:"rotation"`
+	// Addr - host:port of the tcp or udp sink, every entry is sent as a line.
+	Addr string `yaml:"addr"`
+}
+
+// LogRotationConfig - the file is rotated when the month changes, and when it exceeds MaxSize or the day changes
+// if Daily. The rotated files are renamed to {name}-{rotation time}{ext} in the same directory.
+type LogRotationConfig struct {
+	// MaxSize - the max size of the file in megabytes, 0 means no limit.
+	MaxSize int `yaml:"maxSize"`
+	// Daily - rotates the file when the day changes.
+	Daily bool `yaml:"daily"`
+	// MaxAge - removes the rotated files older than it, 0 means no limit.
+	MaxAge time.Duration `yaml:"maxAge"`
+	// MaxBackups - keeps at most the number of the rotated files, 0 means no limit.
+	MaxBackups int `yaml:"maxBackups"`
+	// Compress - compresses the rotated files with gzip.
+	Compress bool `yaml:"compress"`
 }
 
 // WebConfig -
@@ -92,6 +207,11 @@ type WebConfig struct {
 	Swagger            *SwaggerConfig      `yaml:"swagger"`
 	MaxMultipartMemory int64               `yaml:"maxMultipartMemory"`
 	SensitiveURLPaths  map[string]struct{} `yaml:"sensitiveURLPaths"`
+	// Timeout - the default timeout of the routes, 0 means no timeout.
+	// The budget in the X-Timeout header of the request shortens it, see web.Deadline.
+	Timeout time.Duration `yaml:"timeout"`
+	// RouteTimeouts - the timeouts by "METHOD /route", such as "GET /users/:id", which override Timeout.
+	RouteTimeouts map[string]time.Duration `yaml:"routeTimeouts"`
 }
 
 func (conf *WebConfig) IsSensitiveURLPath(path string) bool {
@@ -99,6 +219,14 @@ func (conf *WebConfig) IsSensitiveURLPath(path string) bool {
 	return ok
 }
 
+// RouteTimeout - the timeout of the route, see RouteTimeouts.
+func (conf *WebConfig) RouteTimeout(method string, route string) time.Duration {
+	if timeout, ok := conf.RouteTimeouts[method+" "+route]; ok {
+		return timeout
+	}
+	return conf.Timeout
+}
+
 // SwaggerConfig -
 type SwaggerConfig struct {
 	Enabled bool   `yaml:"enabled"`
@@ -113,6 +241,19 @@ type RPCConfig struct {
 	RegisterHealthServer     *bool                       `yaml:"registerHealthServer"`
 	RegisterReflectionServer *bool                       `yaml:"registerReflectionServer"`
 	Clients                  map[string]*RPCClientConfig `yaml:"clients"`
+	// Timeout - the default timeout of the methods, 0 means no timeout.
+	// The deadline propagated by the caller shortens it.
+	Timeout time.Duration `yaml:"timeout"`
+	// MethodTimeouts - the timeouts by the full method names, such as /pkg.Service/Method, which override Timeout.
+	MethodTimeouts map[string]time.Duration `yaml:"methodTimeouts"`
+}
+
+// MethodTimeout - the timeout of the method, see MethodTimeouts.
+func (conf *RPCConfig) MethodTimeout(fullMethod string) time.Duration {
+	if timeout, ok := conf.MethodTimeouts[fullMethod]; ok {
+		return timeout
+	}
+	return conf.Timeout
 }
 
 // RPCClientConfig -
@@ -122,12 +263,14 @@ type RPCClientConfig struct {
 	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
 	MaxCallSendMsgSize int    `yaml:"maxCallSendMsgSize"`
 	MaxCallRecvMsgSize int    `yaml:"maxCallRecvMsgSize"`
+	// Timeout - the default timeout of the calls whose context has no deadline, 0 means no timeout.
+	Timeout time.Duration `yaml:"timeout"`
 }
 
 // DbConfig -
 type DbConfig struct {
 	Username               string        `yaml:"username"`
-	Password               string        `yaml:"password"`
+	Password               string        `yaml:"password" secret:"true"`
 	Host                   string        `yaml:"host"`
 	Port                   int32         `yaml:"port"`
 	Database               string        `yaml:"database"`
@@ -143,7 +286,7 @@ type DbConfig struct {
 
 // RedisConfig -
 type RedisConfig struct {
-	Password        string               `yaml:"password"`
+	Password        string               `yaml:"password" secret:"true"`
 	Host            string               `yaml:"host"`
 	Port            int32                `yaml:"port"`
 	Database        uint8                `yaml:"database"`
@@ -182,6 +325,53 @@ type MetricsConfig struct {
 	GoCollector        *bool  `yaml:"goCollector"`
 }
 
+// the propagators of the trace
+const (
+	// TracePropagatorW3C - traceparent and tracestate of W3C Trace Context.
+	TracePropagatorW3C = "w3c"
+	// TracePropagatorB3 - the X-B3-* headers of B3, the single b3 header is accepted as well.
+	TracePropagatorB3 = "b3"
+)
+
+// the exporters of the spans
+const (
+	// TraceExporterOTLP - exports the spans to an OTLP collector by grpc.
+	TraceExporterOTLP = "otlp"
+	// TraceExporterStdout - prints the spans to stdout as json.
+	TraceExporterStdout = "stdout"
+	// TraceExporterMemory - keeps the spans in memory for the tests, see ntrace.MemoryExporter.
+	TraceExporterMemory = "memory"
+)
+
+// TraceConfig -
+type TraceConfig struct {
+	// Propagators - the trace headers accepted by the servers in order and sent by the clients besides X-Trace-ID,
+	// w3c or b3, it defaults to [w3c].
+	Propagators []string `yaml:"propagators"`
+	// Enabled - creates the spans by OpenTelemetry, see ntrace.InitTracing.
+	Enabled bool `yaml:"enabled"`
+	// Exporter - otlp, stdout or memory, it defaults to otlp.
+	Exporter string `yaml:"exporter"`
+	// Endpoint - the host:port of the OTLP collector, it defaults to OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4317.
+	Endpoint string `yaml:"endpoint"`
+	// Insecure - connects to the OTLP collector without TLS.
+	Insecure bool `yaml:"insecure"`
+	// SampleRatio - the ratio of the traces started by the service which are sampled, it defaults to 1.
+	// The traces of the callers follow their sampled flags.
+	SampleRatio *float64 `yaml:"sampleRatio"`
+	// Baggage - propagates the MDC others across the services, see TraceBaggageConfig.
+	Baggage *TraceBaggageConfig `yaml:"baggage"`
+}
+
+// TraceBaggageConfig - the MDC others of Keys are sent by the clients in the baggage header of W3C Baggage,
+// and set to the MDC by the servers. The values are sent as strings, and the entries beyond MaxBytes are dropped.
+type TraceBaggageConfig struct {
+	// Keys - the keys of the MDC others, such as tenantID.
+	Keys []string `yaml:"keys"`
+	// MaxBytes - the max length of the baggage header, it defaults to 8192.
+	MaxBytes int `yaml:"maxBytes"`
+}
+
 // GraceTerminationConfig -
 type GraceTerminationConfig struct {
 	GraceTerminationPeriod time.Duration `yaml:"graceTerminationPeriod"`
@@ -191,10 +381,48 @@ type GraceTerminationConfig struct {
 type CronConfig struct {
 	SkipIfStillRunning *bool            `yaml:"skipIfStillRunning"`
 	CronJobs           []*CronJobConfig `yaml:"cronJobs"`
+	// Timeout - the default timeout of the runs of the jobs, 0 means no timeout.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// JobTimeout - the timeout of the runs of the job, its own Timeout overrides the default one.
+func (conf *CronConfig) JobTimeout(job *CronJobConfig) time.Duration {
+	if job.Timeout > 0 {
+		return job.Timeout
+	}
+	return conf.Timeout
 }
 
 // CronJobConfig -
 type CronJobConfig struct {
-	Name     string `yaml:"name"`
-	Schedule string `yaml:"schedule"`
+	Name     string        `yaml:"name"`
+	Schedule string        `yaml:"schedule"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// FeaturesConfig - the feature flags by their names.
+type FeaturesConfig map[string]*FeatureConfig
+
+// FeatureConfig - a feature flag, which can also be written as a bool, e.g. newCheckout: true.
+//
+// A flag is off if it is not enabled. Otherwise it is on for the client types in ClientTypes, and for the
+// subjects which fall in the rollout Percentage. Without ClientTypes or Percentage it is on for everyone.
+type FeatureConfig struct {
+	// Enabled - the switch of the flag, default is true.
+	Enabled *bool `yaml:"enabled"`
+	// Percentage - the percentage [0, 100] of the subjects, by a hash of the flag name and the subject id.
+	Percentage *float64 `yaml:"percentage"`
+	// ClientTypes - the allowlist of the client types.
+	ClientTypes []string `yaml:"clientTypes"`
+}
+
+// UnmarshalYAML - accepts a bool as the switch of the flag.
+func (conf *FeatureConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var enabled bool
+	if err := unmarshal(&enabled); err == nil {
+		*conf = FeatureConfig{Enabled: &enabled}
+		return nil
+	}
+	type featureConfig FeatureConfig
+	return unmarshal((*featureConfig)(conf))
 }
@@ -25,6 +25,9 @@ func (conf *Config) SetDefaultValues() {
 	if conf.Log == nil {
 		conf.Log = &LogConfig{}
 	}
+	if conf.Trace == nil {
+		conf.Trace = &TraceConfig{}
+	}
 	configs := []interface{ SetDefaultValues() }{
 		conf.App,
 		conf.DB,
@@ -33,6 +36,7 @@ func (conf *Config) SetDefaultValues() {
 		conf.RPC,
 		conf.CronConfig,
 		conf.Metrics,
+		conf.Trace,
 	}
 	for _, c := range configs {
 		if ntypes.IsNotNil(c) {
@@ -57,6 +61,9 @@ func (conf *LogConfig) SetDefaultValues() {
 
 // SetDefaultValues -
 func (conf *DbConfig) SetDefaultValues() {
+	if conf.Port == 0 {
+		conf.Port = 3306
+	}
 	if conf.MaxOpen == 0 {
 		conf.MaxOpen = 2
 	}
@@ -76,6 +83,9 @@ func (conf *DbConfig) SetDefaultValues() {
 
 // SetDefaultValues -
 func (conf *RedisConfig) SetDefaultValues() {
+	if conf.Port == 0 {
+		conf.Port = 6379
+	}
 	if conf.MaxActive == 0 {
 		conf.MaxActive = 5
 	}
@@ -172,3 +182,10 @@ func (conf *CronConfig) SetDefaultValues() {
 		conf.SkipIfStillRunning = ntypes.Bool(true)
 	}
 }
+
+// SetDefaultValues -
+func (conf *TraceConfig) SetDefaultValues() {
+	if len(conf.Propagators) == 0 {
+		conf.Propagators = []string{TracePropagatorW3C}
+	}
+}
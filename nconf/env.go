@@ -0,0 +1,336 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nf-go/nfgo/nerrors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// EnvOverridePrefix - the prefix of the env vars which override the config values,
+// e.g. NFGO_DB_HOST overrides db.host and NFGO_RPC_CLIENTS_USER_ADDR overrides rpc.clients.user.addr.
+const EnvOverridePrefix = "NFGO_"
+
+var envPlaceholderRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:([^}]*))?\}`)
+
+// expandEnvPlaceholders - replaces ${ENV_NAME:default} in the string values of the parsed tree with the value of
+// the env var, the default value is used when the env var is not set, and ${ENV_NAME} without default requires
+// the env var to be set. The values are never parsed as yaml, so they can not change the structure of the tree,
+// but a value which is a placeholder only is typed as a bool or a number if the expanded value is one.
+func expandEnvPlaceholders(tree map[string]interface{}) error {
+	var err error
+	for key, val := range tree {
+		tree[key] = expandEnvValue(val, &err)
+	}
+	return err
+}
+
+func expandEnvValue(val interface{}, err *error) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = expandEnvValue(child, err)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = expandEnvValue(child, err)
+		}
+	case string:
+		return expandEnvString(v, err)
+	}
+	return val
+}
+
+func expandEnvString(s string, err *error) interface{} {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	expanded := envPlaceholderRegexp.ReplaceAllStringFunc(s, func(placeholder string) string {
+		groups := envPlaceholderRegexp.FindStringSubmatch(placeholder)
+		name, hasDefault := groups[1], groups[2] != ""
+		if val, ok := os.LookupEnv(name); ok {
+			return val
+		}
+		if !hasDefault {
+			*err = nerrors.Append(*err, nerrors.Errorf("env var %s is not set", name))
+			return placeholder
+		}
+		return groups[3]
+	})
+	if loc := envPlaceholderRegexp.FindStringIndex(s); loc != nil && loc[0] == 0 && loc[1] == len(s) {
+		return parseEnvScalar(expanded)
+	}
+	return expanded
+}
+
+// parseEnvScalar - types the value as a bool or a number, the others are kept as strings.
+func parseEnvScalar(val string) interface{} {
+	var parsed interface{}
+	if e := yaml.Unmarshal([]byte(val), &parsed); e != nil {
+		return val
+	}
+	switch parsed.(type) {
+	case bool, int, int64, uint64, float64:
+		return parsed
+	}
+	return val
+}
+
+// NewEnvSource - a source of the env vars with the prefix NFGO_ (see EnvPrefixOption), the names are resolved
+// against the fields of Config, the custom config set by SchemaOption and the keys of the lower sources.
+func NewEnvSource(opt ...SourceOption) Source {
+	return &envSource{
+		opts:    newSourceOptions(opt),
+		environ: os.Environ,
+	}
+}
+
+type envSource struct {
+	opts    *sourceOptions
+	environ func() []string
+}
+
+func (s *envSource) Load(base map[string]interface{}) (map[string]interface{}, error) {
+	overrides := map[string]string{}
+	for _, kv := range s.environ() {
+		if key, val, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(key, s.opts.envPrefix) && key != s.opts.envPrefix {
+			overrides[strings.TrimPrefix(key, s.opts.envPrefix)] = val
+		}
+	}
+	return applyOverrides(base, overrides, s.opts.schema, func(key string) []string {
+		return strings.Split(key, "_")
+	}), nil
+}
+
+func (s *envSource) String() string {
+	return "env " + s.opts.envPrefix
+}
+
+// applyOverrides - resolves the names of the overrides to the config keys and returns them as a tree,
+// the names which can not be resolved are ignored.
+func applyOverrides(base map[string]interface{}, overrides map[string]string, schema []reflect.Type,
+	split func(name string) []string) map[string]interface{} {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	types := append([]reflect.Type{reflect.TypeOf(Config{})}, schema...)
+	tree := map[string]interface{}{}
+	for _, name := range names {
+		if path, kind, ok := resolveKeyPath(types, base, split(name)); ok {
+			setTreeValue(tree, path, parseEnvValue(overrides[name], kind))
+		}
+	}
+	return tree
+}
+
+// resolveKeyPath - resolves the segments against the types in order, then against the top-level keys of the base,
+// so that the keys of a custom config without schema can still be overridden if a lower source has them.
+func resolveKeyPath(types []reflect.Type, base map[string]interface{}, segs []string) ([]string, reflect.Kind, bool) {
+	for _, typ := range types {
+		if path, kind, ok := resolveEnvPath(typ, base, segs); ok {
+			return path, kind, true
+		}
+	}
+	return resolveExistingMapKey(nil, base, segs)
+}
+
+// resolveEnvPath - resolves the underscore separated segments of an env var name to the path of the config keys,
+// struct fields are matched by their yaml names, map keys are matched against the existing keys or are
+// created in lowerCamelCase, the comparison ignores case and separators.
+func resolveEnvPath(typ reflect.Type, node interface{}, segs []string) ([]string, reflect.Kind, bool) {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if len(segs) == 0 {
+		return nil, reflect.Invalid, false
+	}
+
+	kind := reflect.Interface
+	if typ != nil {
+		kind = typ.Kind()
+	}
+	switch kind {
+	case reflect.Struct:
+		for i := 1; i <= len(segs); i++ {
+			name := normalizeKey(strings.Join(segs[:i], ""))
+			for _, field := range structFields(typ) {
+				if normalizeKey(field.name) != name {
+					continue
+				}
+				if path, kind, ok := resolveChild(field.typ, node, field.name, segs[i:]); ok {
+					return path, kind, true
+				}
+			}
+		}
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return nil, reflect.Invalid, false
+		}
+		return resolveMapKey(typ.Elem(), node, segs)
+	case reflect.Interface:
+		return resolveMapKey(nil, node, segs)
+	}
+	return nil, reflect.Invalid, false
+}
+
+func resolveMapKey(elemType reflect.Type, node interface{}, segs []string) ([]string, reflect.Kind, bool) {
+	if elemType != nil && elemType.Kind() == reflect.Interface {
+		elemType = nil
+	}
+	if path, kind, ok := resolveExistingMapKey(elemType, node, segs); ok {
+		return path, kind, true
+	}
+	if elemType == nil {
+		return []string{lowerCamelKey(segs)}, reflect.Interface, true
+	}
+	for i := 1; i <= len(segs); i++ {
+		if path, kind, ok := resolveChild(elemType, nil, lowerCamelKey(segs[:i]), segs[i:]); ok {
+			return path, kind, true
+		}
+	}
+	return nil, reflect.Invalid, false
+}
+
+func resolveExistingMapKey(elemType reflect.Type, node interface{}, segs []string) ([]string, reflect.Kind, bool) {
+	m, _ := node.(map[string]interface{})
+	for i := 1; i <= len(segs); i++ {
+		name := normalizeKey(strings.Join(segs[:i], ""))
+		for key := range m {
+			if normalizeKey(key) == name {
+				if path, kind, ok := resolveChild(elemType, m, key, segs[i:]); ok {
+					return path, kind, true
+				}
+			}
+		}
+	}
+	return nil, reflect.Invalid, false
+}
+
+func resolveChild(typ reflect.Type, node interface{}, key string, segs []string) ([]string, reflect.Kind, bool) {
+	var child interface{}
+	if m, ok := node.(map[string]interface{}); ok {
+		child = m[key]
+	}
+	if len(segs) == 0 {
+		kind := reflect.Interface
+		for typ != nil && typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ != nil {
+			kind = typ.Kind()
+		}
+		if kind == reflect.Struct || kind == reflect.Map {
+			return nil, reflect.Invalid, false
+		}
+		return []string{key}, kind, true
+	}
+	path, kind, ok := resolveEnvPath(typ, child, segs)
+	if !ok {
+		return nil, reflect.Invalid, false
+	}
+	return append([]string{key}, path...), kind, true
+}
+
+type structField struct {
+	name string
+	typ  reflect.Type
+}
+
+// structFields - returns the fields of the struct by their yaml names, the inline fields are flattened.
+func structFields(typ reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				fields = append(fields, structFields(fieldType)...)
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields = append(fields, structField{name: name, typ: field.Type})
+	}
+	return fields
+}
+
+func setTreeValue(tree map[string]interface{}, path []string, value interface{}) {
+	node := tree
+	for _, key := range path[:len(path)-1] {
+		child, ok := node[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			node[key] = child
+		}
+		node = child
+	}
+	node[path[len(path)-1]] = value
+}
+
+// parseEnvValue - keeps the raw value for string fields, otherwise parses the value as a yaml scalar or a yaml flow collection.
+func parseEnvValue(val string, kind reflect.Kind) interface{} {
+	if kind == reflect.String {
+		return val
+	}
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(val), &parsed); err != nil || parsed == nil {
+		return val
+	}
+	return normalizeTree(parsed)
+}
+
+func normalizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, key)
+}
+
+func lowerCamelKey(segs []string) string {
+	var sb strings.Builder
+	for i, seg := range segs {
+		seg = strings.ToLower(seg)
+		if i > 0 && seg != "" {
+			seg = strings.ToUpper(seg[:1]) + seg[1:]
+		}
+		sb.WriteString(seg)
+	}
+	return sb.String()
+}
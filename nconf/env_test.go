@@ -0,0 +1,132 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandEnvPlaceholders(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("NFGO_TEST_HOST", "10.0.0.1")
+
+	tree, err := parseTree([]byte("host: ${NFGO_TEST_HOST:127.0.0.1}\nport: ${NFGO_TEST_PORT:3306}\n"+
+		"pass: ${NFGO_TEST_PASS:}\naddr: ${NFGO_TEST_HOST}:${NFGO_TEST_PORT:3306}\nhosts: [\"${NFGO_TEST_HOST}\"]"), FormatYAML)
+	a.Nil(err)
+	a.Nil(expandEnvPlaceholders(tree))
+	a.Equal(map[string]interface{}{
+		"host":  "10.0.0.1",
+		"port":  3306,
+		"pass":  "",
+		"addr":  "10.0.0.1:3306",
+		"hosts": []interface{}{"10.0.0.1"},
+	}, tree)
+
+	tree, _ = parseTree([]byte("host: ${NFGO_TEST_NOT_EXIST}"), FormatYAML)
+	err = expandEnvPlaceholders(tree)
+	a.Contains(err.Error(), "env var NFGO_TEST_NOT_EXIST is not set")
+}
+
+func TestExpandEnvPlaceholdersVerbatim(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("NFGO_TEST_HASH", "pa#ss #word")
+	t.Setenv("NFGO_TEST_COLON", "user: admin")
+	t.Setenv("NFGO_TEST_BRACKET", "[not, a, list]")
+	t.Setenv("NFGO_TEST_ANCHOR", "*alias")
+
+	config, err := NewConfig([]byte(`
+# the password is ${NFGO_TEST_NOT_EXIST} in the comment
+db:
+  password: ${NFGO_TEST_HASH}
+  username: ${NFGO_TEST_COLON} # ${NFGO_TEST_NOT_EXIST}
+  host: ${NFGO_TEST_BRACKET}
+  database: ${NFGO_TEST_ANCHOR}
+  port: 3306
+`))
+	a.Nil(err)
+	a.Equal("pa#ss #word", config.DB.Password)
+	a.Equal("user: admin", config.DB.Username)
+	a.Equal("[not, a, list]", config.DB.Host)
+	a.Equal("*alias", config.DB.Database)
+	a.Equal(int32(3306), config.DB.Port)
+}
+
+func TestEnvSource(t *testing.T) {
+	a := assert.New(t)
+	data := []byte(`
+app:
+  name: foo
+  ext:
+    payment:
+      timeout: 3s
+db:
+  host: 127.0.0.1
+  database: test
+  username: root
+rpc:
+  clients:
+    user-svc:
+      addr: 127.0.0.1:9090
+`)
+	environ := []string{
+		"NFGO_DB_HOST=10.0.0.1",
+		"NFGO_DB_PORT=3307",
+		"NFGO_DB_MAX_IDLE_TIME=5m",
+		"NFGO_RPC_CLIENTS_USER_SVC_ADDR=user-svc:9090",
+		"NFGO_RPC_CLIENTS_ORDER_ADDR=order-svc:9090",
+		"NFGO_APP_EXT_PAYMENT_TIMEOUT=5s",
+		"NFGO_APP_EXT_FEATURE_ENABLED=true",
+		"NFGO_NOT_EXIST=1",
+		"PATH=/usr/bin",
+	}
+	src := NewEnvSource()
+	src.(*envSource).environ = func() []string { return environ }
+
+	config, err := NewConfigFromSources(nil, NewBytesSource(data), src)
+	a.Nil(err)
+	a.Equal("foo", config.App.Name)
+	a.Equal("10.0.0.1", config.DB.Host)
+	a.Equal(int32(3307), config.DB.Port)
+	a.Equal(5*time.Minute, config.DB.MaxIdleTime)
+	a.Equal("user-svc:9090", config.RPC.Clients["user-svc"].Addr)
+	a.Equal("order-svc:9090", config.RPC.Clients["order"].Addr)
+	a.Equal(map[interface{}]interface{}{"timeout": "5s"}, config.App.Ext["payment"])
+	a.Equal(true, config.App.Ext["featureEnabled"])
+}
+
+func TestEnvSourceCustomConfig(t *testing.T) {
+	a := assert.New(t)
+	t.Setenv("NFGO_FOO", "f2")
+	t.Setenv("NFGO_LOG_LEVEL", "debug")
+
+	data := []byte("log:\n  level: info\nfoo: f1\n")
+	confPath := filepath.Join(t.TempDir(), "app.yaml")
+	a.Nil(os.WriteFile(confPath, data, 0o600))
+	fooConfig := &FooConfig{}
+	config := MustLoadConfigCustom(confPath, fooConfig)
+	a.Equal("f2", fooConfig.Foo)
+	a.Equal("debug", config.Log.Level)
+
+	// the configs of the bytes are not overridden by the env vars
+	fooConfig = &FooConfig{}
+	config = MustNewConfigCustom(data, fooConfig)
+	a.Equal("f1", fooConfig.Foo)
+	a.Equal("info", config.Log.Level)
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nf-go/nfgo/nerrors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ExtConfig - the settings of the application in app.ext.
+//
+// The accessors take a dotted path such as payment.timeout, a key which contains dots itself is matched first.
+// They convert the value leniently, e.g. "3" or 3.0 is an int, and return the default (or the zero value)
+// when the path is not set or the value can not be converted.
+type ExtConfig map[string]interface{}
+
+// Get - returns the value at the dotted path.
+func (e ExtConfig) Get(path string) (interface{}, bool) {
+	return lookupPath(map[string]interface{}(e), path)
+}
+
+// Has - whether the dotted path is set.
+func (e ExtConfig) Has(path string) bool {
+	_, ok := e.Get(path)
+	return ok
+}
+
+// StrVal -
+func (e ExtConfig) StrVal(path string, def ...string) string {
+	if v, ok := e.Get(path); ok {
+		switch val := v.(type) {
+		case string:
+			return val
+		case int, int64, uint64, float64, bool:
+			return fmt.Sprint(val)
+		}
+	}
+	return firstOrZero(def)
+}
+
+// IntVal -
+func (e ExtConfig) IntVal(path string, def ...int) int {
+	if v, ok := e.Get(path); ok {
+		if val, ok := toInt64(v); ok && val >= math.MinInt && val <= math.MaxInt {
+			return int(val)
+		}
+	}
+	return firstOrZero(def)
+}
+
+// Int64Val -
+func (e ExtConfig) Int64Val(path string, def ...int64) int64 {
+	if v, ok := e.Get(path); ok {
+		if val, ok := toInt64(v); ok {
+			return val
+		}
+	}
+	return firstOrZero(def)
+}
+
+// FloatVal -
+func (e ExtConfig) FloatVal(path string, def ...float64) float64 {
+	if v, ok := e.Get(path); ok {
+		if val, ok := toFloat64(v); ok {
+			return val
+		}
+	}
+	return firstOrZero(def)
+}
+
+// BoolVal -
+func (e ExtConfig) BoolVal(path string, def ...bool) bool {
+	if v, ok := e.Get(path); ok {
+		switch val := v.(type) {
+		case bool:
+			return val
+		case string:
+			if b, err := strconv.ParseBool(val); err == nil {
+				return b
+			}
+		}
+	}
+	return firstOrZero(def)
+}
+
+// DurationVal - the value is a duration string such as 3s, or an integer of nanoseconds as yaml decodes it.
+func (e ExtConfig) DurationVal(path string, def ...time.Duration) time.Duration {
+	if v, ok := e.Get(path); ok {
+		if s, ok := v.(string); ok {
+			if d, err := time.ParseDuration(s); err == nil {
+				return d
+			}
+		} else if n, ok := toInt64(v); ok {
+			return time.Duration(n)
+		}
+	}
+	return firstOrZero(def)
+}
+
+// SliceVal -
+func (e ExtConfig) SliceVal(path string, def ...interface{}) []interface{} {
+	if v, ok := e.Get(path); ok {
+		if val, ok := v.([]interface{}); ok {
+			return val
+		}
+	}
+	return def
+}
+
+// StrSliceVal - the elements are converted as StrVal does, a single scalar is a slice of one element.
+func (e ExtConfig) StrSliceVal(path string, def ...string) []string {
+	v, ok := e.Get(path)
+	if !ok {
+		return def
+	}
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+	strs := make([]string, 0, len(items))
+	for _, item := range items {
+		switch val := item.(type) {
+		case string:
+			strs = append(strs, val)
+		case int, int64, uint64, float64, bool:
+			strs = append(strs, fmt.Sprint(val))
+		default:
+			return def
+		}
+	}
+	return strs
+}
+
+// MapVal - returns the map at the dotted path as an ExtConfig, so that it can be accessed the same way.
+func (e ExtConfig) MapVal(path string) ExtConfig {
+	if v, ok := e.Get(path); ok {
+		if m, ok := normalizeTree(v).(map[string]interface{}); ok {
+			return ExtConfig(m)
+		}
+	}
+	return nil
+}
+
+// Unmarshal - decodes the value at the dotted path into out by the yaml tags, an empty path decodes the whole ext.
+// out is left untouched if the path is not set, so its fields can be preset as the defaults.
+// Then out is validated if it has a method Validate() error.
+func (e ExtConfig) Unmarshal(path string, out interface{}) error {
+	var v interface{} = map[string]interface{}(e)
+	if path != "" {
+		var ok bool
+		if v, ok = e.Get(path); !ok {
+			v = nil
+		}
+	}
+	if v != nil {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return nerrors.Wrapf(err, "fail to marshal ext %s", path)
+		}
+		if err := yaml.Unmarshal(data, out); err != nil {
+			return nerrors.Wrapf(err, "fail to unmarshal ext %s", path)
+		}
+	}
+	if validator, ok := out.(interface{ Validate() error }); ok {
+		if err := validator.Validate(); err != nil {
+			return nerrors.Wrapf(err, "ext %s is invalid", path)
+		}
+	}
+	return nil
+}
+
+// lookupPath - looks up the dotted path in the nested maps, the longest key which matches a prefix wins.
+func lookupPath(node interface{}, path string) (interface{}, bool) {
+	var get func(key string) (interface{}, bool)
+	switch m := node.(type) {
+	case map[string]interface{}:
+		get = func(key string) (interface{}, bool) {
+			v, ok := m[key]
+			return v, ok
+		}
+	case map[interface{}]interface{}:
+		get = func(key string) (interface{}, bool) {
+			v, ok := m[key]
+			return v, ok
+		}
+	default:
+		return nil, false
+	}
+
+	if v, ok := get(path); ok {
+		return v, true
+	}
+	for i := strings.LastIndex(path, "."); i > 0; i = strings.LastIndex(path[:i], ".") {
+		if child, ok := get(path[:i]); ok {
+			if v, ok := lookupPath(child, path[i+1:]); ok {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch val := v.(type) {
+	case int:
+		return int64(val), true
+	case int64:
+		return val, true
+	case uint64:
+		if val <= math.MaxInt64 {
+			return int64(val), true
+		}
+	case float64:
+		// math.MaxInt64 is rounded up to 2^63 as a float64, which overflows int64
+		if val == math.Trunc(val) && val >= math.MinInt64 && val < math.MaxInt64 {
+			return int64(val), true
+		}
+	case string:
+		if n, err := strconv.ParseInt(strings.TrimSpace(val), 10, 64); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(val), 64); err == nil {
+			return f, true
+		}
+	}
+	if n, ok := toInt64(v); ok {
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func firstOrZero[T any](def []T) T {
+	if len(def) > 0 {
+		return def[0]
+	}
+	var zero T
+	return zero
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+type paymentConfig struct {
+	Timeout  time.Duration `yaml:"timeout"`
+	Retries  int           `yaml:"retries"`
+	Channels []string      `yaml:"channels"`
+}
+
+func (c *paymentConfig) Validate() error {
+	if c.Retries < 0 {
+		return nerrors.Errorf("retries %d must not be negative", c.Retries)
+	}
+	return nil
+}
+
+func TestExtConfig(t *testing.T) {
+	a := assert.New(t)
+	config := MustNewConfig([]byte(`
+app:
+  ext:
+    rate: 2
+    count: "3"
+    ratio: "0.5"
+    enabled: "true"
+    feature.flag: on
+    payment:
+      timeout: 3s
+      retries: 3
+      channels: [alipay, wechat]
+      limits:
+        daily: 1000
+    bad:
+      retries: -1
+`))
+	ext := config.App.Ext
+
+	v, ok := ext.Get("payment.limits.daily")
+	a.True(ok)
+	a.Equal(1000, v)
+	a.False(ext.Has("payment.notExist"))
+	a.True(ext.BoolVal("feature.flag"))
+	a.True(ext.BoolVal("enabled"))
+	a.True(ext.BoolVal("notExist", true))
+
+	a.Equal("2", ext.StrVal("rate"))
+	a.Equal("def", ext.StrVal("notExist", "def"))
+	a.Equal(3, ext.IntVal("count"))
+	a.Equal(1000, ext.IntVal("payment.limits.daily"))
+	a.Equal(1000.0, ext.FloatVal("payment.limits.daily"))
+	a.Equal(int64(2), ext.Int64Val("rate"))
+	a.Equal(7, ext.IntVal("payment.timeout", 7))
+	a.Equal(0.5, ext.FloatVal("ratio"))
+	a.Equal(2.0, ext.FloatVal("rate"))
+	a.Equal(3*time.Second, ext.DurationVal("payment.timeout"))
+	a.Equal(time.Minute, ext.DurationVal("payment.notExist", time.Minute))
+	a.Equal([]string{"alipay", "wechat"}, ext.StrSliceVal("payment.channels"))
+	a.Equal([]string{"2"}, ext.StrSliceVal("rate"))
+	a.Len(ext.SliceVal("payment.channels"), 2)
+	a.Equal(1000, ext.MapVal("payment").IntVal("limits.daily"))
+	a.Nil(ext.MapVal("rate"))
+
+	payment := &paymentConfig{}
+	a.Nil(ext.Unmarshal("payment", payment))
+	a.Equal(&paymentConfig{Timeout: 3 * time.Second, Retries: 3, Channels: []string{"alipay", "wechat"}}, payment)
+
+	payment = &paymentConfig{Retries: 1}
+	a.Nil(ext.Unmarshal("notExist", payment))
+	a.Equal(1, payment.Retries)
+
+	err := ext.Unmarshal("bad", &paymentConfig{})
+	a.Contains(err.Error(), "ext bad is invalid: retries -1 must not be negative")
+}
+
+func TestToInt64(t *testing.T) {
+	a := assert.New(t)
+	n, ok := toInt64(float64(-1 << 63))
+	a.True(ok)
+	a.Equal(int64(math.MinInt64), n)
+	_, ok = toInt64(float64(1 << 63))
+	a.False(ok)
+	_, ok = toInt64(1.5)
+	a.False(ok)
+	_, ok = toInt64(uint64(1 << 63))
+	a.False(ok)
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/nf-go/nfgo/nerrors"
+	toml "github.com/pelletier/go-toml/v2"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Format - the format of the config data, the keys are the yaml names of the config fields in every format.
+type Format string
+
+// the formats
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+	FormatTOML Format = "toml"
+)
+
+// FormatOf - detects the format by the extension of the path, .json and .toml are detected and others are yaml.
+func FormatOf(path string) Format {
+	if format, ok := formatOfExt(path); ok {
+		return format
+	}
+	return FormatYAML
+}
+
+func formatOfExt(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML, true
+	case ".json":
+		return FormatJSON, true
+	case ".toml":
+		return FormatTOML, true
+	}
+	return "", false
+}
+
+// formatOfContentType - detects the format by the content type of a http response, default is json.
+func formatOfContentType(contentType string) Format {
+	switch {
+	case strings.Contains(contentType, "yaml"):
+		return FormatYAML
+	case strings.Contains(contentType, "toml"):
+		return FormatTOML
+	}
+	return FormatJSON
+}
+
+func parseTree(data []byte, format Format) (map[string]interface{}, error) {
+	switch format {
+	case FormatJSON:
+		return parseJSONTree(data)
+	case FormatTOML:
+		return parseTOMLTree(data)
+	case FormatYAML, "":
+		return parseYAMLTree(data)
+	}
+	return nil, nerrors.Errorf("unknown config format %q", format)
+}
+
+func parseYAMLTree(data []byte) (map[string]interface{}, error) {
+	var tree interface{}
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	if tree == nil {
+		return map[string]interface{}{}, nil
+	}
+	m, ok := normalizeTree(tree).(map[string]interface{})
+	if !ok {
+		return nil, nerrors.Errorf("the config is a %T rather than a map", tree)
+	}
+	return m, nil
+}
+
+// parseJSONTree - parses the json object, the integral numbers are kept as int64 as yaml does.
+func parseJSONTree(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tree map[string]interface{}
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, nerrors.Wrap(err, "fail to parse json")
+	}
+	if tree == nil {
+		tree = map[string]interface{}{}
+	}
+	return normalizeJSONNumbers(tree).(map[string]interface{}), nil
+}
+
+func normalizeJSONNumbers(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, val := range t {
+			t[key] = normalizeJSONNumbers(val)
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = normalizeJSONNumbers(val)
+		}
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	}
+	return v
+}
+
+func parseTOMLTree(data []byte) (map[string]interface{}, error) {
+	tree := map[string]interface{}{}
+	if err := toml.NewDecoder(bytes.NewReader(data)).Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfigFormats(t *testing.T) {
+	a := assert.New(t)
+	dir := writeConfigFiles(t, map[string]string{
+		"common.toml": `
+[db]
+host = "127.0.0.1"
+port = 3306
+maxIdleTime = "5m"
+`,
+		"app.json": `{
+	"include": "common.toml",
+	"app": {"name": "foo", "profile": "dev", "ext": {"ratio": 0.5, "retries": 3}},
+	"db": {"database": "test", "username": "root"}
+}`,
+		"app-dev.json": `{"db": {"port": 3307}}`,
+	})
+
+	fooConfig := &FooConfig{}
+	config, err := LoadConfigCustom(filepath.Join(dir, "app.json"), fooConfig)
+	a.Nil(err)
+	a.Equal("foo", config.App.Name)
+	a.Equal("127.0.0.1", config.DB.Host)
+	a.Equal(int32(3307), config.DB.Port)
+	a.Equal(5*time.Minute, config.DB.MaxIdleTime)
+	a.Equal(0.5, config.App.Ext.FloatVal("ratio"))
+	a.Equal(3, config.App.Ext["retries"])
+
+	config, err = NewConfigFromSources(nil, NewBytesSource([]byte("[web]\nport = 8081\n"), FormatOption(FormatTOML)))
+	a.Nil(err)
+	a.Equal(int32(8081), config.Web.Port)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write([]byte("web:\n  port: 8082\n"))
+	}))
+	defer srv.Close()
+	config, err = NewConfigFromSources(nil, NewHTTPSource(srv.URL))
+	a.Nil(err)
+	a.Equal(int32(8082), config.Web.Port)
+}
+
+func TestJSONSchema(t *testing.T) {
+	a := assert.New(t)
+	data, err := JSONSchema(&barConfig{})
+	a.Nil(err)
+
+	var schema struct {
+		Schema               string `json:"$schema"`
+		AdditionalProperties *bool  `json:"additionalProperties"`
+		Properties           map[string]struct {
+			Properties map[string]map[string]interface{} `json:"properties"`
+		} `json:"properties"`
+	}
+	a.Nil(json.Unmarshal(data, &schema))
+	a.Equal(jsonSchemaDraft, schema.Schema)
+	a.False(*schema.AdditionalProperties)
+	a.Contains(schema.Properties, "bar")
+	a.Contains(schema.Properties, "include")
+	a.NotContains(schema.Properties, "config")
+	a.Equal("string", schema.Properties["db"].Properties["host"]["type"])
+	a.Equal("integer", schema.Properties["db"].Properties["port"]["type"])
+	a.Equal(durationPattern, schema.Properties["db"].Properties["maxIdleTime"]["pattern"])
+	a.Equal("object", schema.Properties["rpc"].Properties["clients"]["type"])
+
+	data, err = JSONSchema(nil)
+	a.Nil(err)
+	a.NotContains(string(data), `"bar"`)
+}
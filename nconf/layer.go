@@ -0,0 +1,179 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nf-go/nfgo/nerrors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// EnvProfile - the env var which selects the profile overlay, it takes precedence over app.profile of the base file.
+	EnvProfile = EnvOverridePrefix + "APP_PROFILE"
+
+	includeKey = "include"
+)
+
+// NewFileSource - a source of the config file with its includes and profile overlay, see ProfileOption.
+// It is watchable, the files are polled every PollIntervalOption.
+func NewFileSource(confPath string, opt ...SourceOption) Source {
+	return &fileSource{
+		confPath: confPath,
+		opts:     newSourceOptions(opt),
+	}
+}
+
+type fileSource struct {
+	confPath string
+	opts     *sourceOptions
+}
+
+func (s *fileSource) Load(base map[string]interface{}) (map[string]interface{}, error) {
+	return loadConfigFiles(s.confPath, s.opts.profile)
+}
+
+func (s *fileSource) Watch(ctx context.Context, notify func()) {
+	pollSource(ctx, s.opts.pollInterval, s, func() ([]byte, error) {
+		tree, err := loadConfigFiles(s.confPath, s.opts.profile)
+		if err != nil {
+			return nil, err
+		}
+		return yaml.Marshal(tree)
+	}, notify)
+}
+
+func (s *fileSource) String() string {
+	return "file " + s.confPath
+}
+
+// loadConfigFiles - loads the config file and deep-merges it with its includes and the profile overlay.
+//
+// The files listed in "include" (a path or a list of paths relative to the including file) are merged first,
+// then the including file itself, so the including file wins. After that app-{profile}.yaml next to the base
+// file is merged if it exists, the profile is the param, or NFGO_APP_PROFILE, or app.profile of the base file.
+//
+// Maps such as rpc.clients and web.sensitiveURLPaths are merged key by key, scalars and lists are replaced
+// by the later file, and a null value removes the key.
+func loadConfigFiles(confPath string, profile string) (map[string]interface{}, error) {
+	if confPath == "" {
+		confPath = "app.yaml"
+	}
+	tree, err := loadConfigFileTree(confPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+	if profile == "" {
+		if app, ok := tree["app"].(map[string]interface{}); ok {
+			profile, _ = app["profile"].(string)
+		}
+	} else {
+		mergeTree(tree, map[string]interface{}{
+			"app": map[string]interface{}{"profile": profile},
+		})
+	}
+
+	if profile != "" {
+		profilePath := profileConfigPath(confPath, profile)
+		profileTree, err := loadConfigFileTree(profilePath, nil)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		mergeTree(tree, profileTree)
+	}
+
+	return tree, nil
+}
+
+// profileConfigPath - app.yaml => app-{profile}.yaml
+func profileConfigPath(confPath string, profile string) string {
+	ext := filepath.Ext(confPath)
+	return strings.TrimSuffix(confPath, ext) + "-" + profile + ext
+}
+
+func loadConfigFileTree(confPath string, including []string) (map[string]interface{}, error) {
+	absPath, err := filepath.Abs(confPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range including {
+		if p == absPath {
+			return nil, nerrors.Errorf("include cycle: %s", strings.Join(append(including, absPath), " -> "))
+		}
+	}
+	including = append(including, absPath)
+
+	data, err := readConfigFile(confPath)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := parseTree(data, FormatOf(confPath))
+	if err != nil {
+		return nil, nerrors.Wrapf(err, "fail to parse %s", confPath)
+	}
+	if err := expandEnvPlaceholders(tree); err != nil {
+		return nil, err
+	}
+
+	includes, err := includePaths(tree[includeKey])
+	if err != nil {
+		return nil, nerrors.Wrapf(err, "invalid include in %s", confPath)
+	}
+	delete(tree, includeKey)
+
+	merged := map[string]interface{}{}
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(confPath), include)
+		}
+		includeTree, err := loadConfigFileTree(include, including)
+		if err != nil {
+			return nil, err
+		}
+		mergeTree(merged, includeTree)
+	}
+	mergeTree(merged, tree)
+	return merged, nil
+}
+
+func includePaths(include interface{}) ([]string, error) {
+	switch v := include.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		paths := make([]string, 0, len(v))
+		for _, p := range v {
+			path, ok := p.(string)
+			if !ok {
+				return nil, nerrors.Errorf("%v is not a path", p)
+			}
+			paths = append(paths, path)
+		}
+		return paths, nil
+	}
+	return nil, nerrors.Errorf("%v is neither a path nor a list of paths", include)
+}
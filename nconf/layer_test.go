@@ -0,0 +1,106 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadConfigFiles(t *testing.T) {
+	a := assert.New(t)
+	dir := writeConfigFiles(t, map[string]string{
+		"common.yaml": `
+log:
+  level: warn
+rpc:
+  clients:
+    user:
+      addr: user:9090
+    order:
+      addr: order:9090
+`,
+		"app.yaml": `
+include: common.yaml
+app:
+  name: foo
+  profile: dev
+web:
+  sensitiveURLPaths:
+    "/login": {}
+    "/debug": {}
+`,
+		"app-dev.yaml": `
+log:
+  level: debug
+rpc:
+  clients:
+    user:
+      addr: 127.0.0.1:9090
+    order: ~
+web:
+  sensitiveURLPaths:
+    "/api/secret": {}
+    "/debug": ~
+`,
+	})
+
+	config, err := LoadConfigCustom(filepath.Join(dir, "app.yaml"), nil)
+	a.Nil(err)
+	a.Equal("foo", config.App.Name)
+	a.Equal("debug", config.Log.Level)
+	a.Equal("127.0.0.1:9090", config.RPC.Clients["user"].Addr)
+	a.NotContains(config.RPC.Clients, "order")
+	a.True(config.Web.IsSensitiveURLPath("/login"))
+	a.True(config.Web.IsSensitiveURLPath("/api/secret"))
+	a.False(config.Web.IsSensitiveURLPath("/debug"))
+}
+
+func TestLoadConfigFilesProfileFromEnv(t *testing.T) {
+	a := assert.New(t)
+	dir := writeConfigFiles(t, map[string]string{
+		"app.yaml":      "app:\n  profile: dev\nlog:\n  level: info\n",
+		"app-dev.yaml":  "log:\n  level: debug\n",
+		"app-prod.yaml": "log:\n  level: error\n",
+	})
+	t.Setenv(EnvProfile, "prod")
+
+	config, err := LoadConfigCustom(filepath.Join(dir, "app.yaml"), nil)
+	a.Nil(err)
+	a.Equal("prod", config.App.Profile)
+	a.Equal("error", config.Log.Level)
+}
+
+func TestLoadConfigFilesIncludeCycle(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"app.yaml": "include: [a.yaml]\n",
+		"a.yaml":   "include: [app.yaml]\n",
+	})
+	_, err := loadConfigFiles(filepath.Join(dir, "app.yaml"), "")
+	assert.Contains(t, err.Error(), "include cycle")
+}
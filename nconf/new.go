@@ -19,43 +19,131 @@ import (
 	"log"
 	"os"
 
+	"github.com/nf-go/nfgo/nerrors"
 	yaml "gopkg.in/yaml.v2"
 )
 
+// CustomConfig - a custom config which extends the Config with the application's own fields.
+type CustomConfig interface {
+	SetConfig(config *Config)
+}
+
+// CustomConfigValidator - a custom config which validates its own fields,
+// the errors are aggregated with the ones of Config.Validate.
+type CustomConfigValidator interface {
+	ValidateCustomConfig() error
+}
+
+// NewConfig - parses the yaml bytes, applies the default values, decrypts the secrets and validates the config.
+// The config is built from the bytes only, the NFGO_ env vars are applied by the file loaders such as LoadConfig,
+// or by NewConfigFromSources with NewEnvSource.
+func NewConfig(bytes []byte) (*Config, error) {
+	return NewConfigCustom(bytes, nil)
+}
+
+// NewConfigCustom - see NewConfig, the NFGO_ env vars are not applied.
+func NewConfigCustom(bytes []byte, customConfig CustomConfig) (*Config, error) {
+	return NewConfigFromSources(customConfig, NewBytesSource(bytes))
+}
+
 // MustNewConfig -
 func MustNewConfig(bytes []byte) *Config {
 	return MustNewConfigCustom(bytes, nil)
 }
 
-// MustNewConfigCustom  -
-func MustNewConfigCustom(bytes []byte, customConfig interface{ SetConfig(config *Config) }) *Config {
+// MustNewConfigCustom  - unlike NewConfigCustom, the problems found by Validate are logged rather than fatal,
+// so that the configs which were accepted before the validation still start. Migrate to NewConfigCustom
+// to fail on them. Like NewConfigCustom, the NFGO_ env vars are not applied.
+func MustNewConfigCustom(bytes []byte, customConfig CustomConfig) *Config {
+	return mustNewConfigLenient("fail to new config: ", customConfig, NewBytesSource(bytes))
+}
+
+// mustNewConfigLenient - builds the config from the sources, the build errors are fatal
+// while the validation errors are only logged.
+func mustNewConfigLenient(fatalMsg string, customConfig CustomConfig, sources ...Source) *Config {
+	tree, err := loadSources(sources)
+	var config *Config
+	if err == nil {
+		config, err = decodeConfig(tree, customConfig)
+	}
+	if err != nil {
+		log.Fatal(fatalMsg, err)
+	}
+	if err := validateConfig(config, customConfig); err != nil {
+		log.Print("the config is invalid, it is accepted for compatibility: ", err)
+	}
+	return config
+}
+
+// buildConfig - builds the config from the tree merged from the sources and validates it.
+func buildConfig(tree map[string]interface{}, customConfig CustomConfig) (*Config, error) {
+	config, err := decodeConfig(tree, customConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateConfig(config, customConfig); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// decodeConfig - decodes the config from the tree, applies the default values and decrypts the secrets.
+func decodeConfig(tree map[string]interface{}, customConfig CustomConfig) (*Config, error) {
+	bytes, err := yaml.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+
 	config := &Config{}
 	if err := yaml.Unmarshal(bytes, config); err != nil {
-		log.Fatal(err)
+		return nil, nerrors.Wrap(err, "fail to parse config")
 	}
 	config.SetDefaultValues()
 
 	// custom config
 	if customConfig != nil {
 		if err := yaml.Unmarshal(bytes, customConfig); err != nil {
-			log.Fatal(err)
-		} else {
-			customConfig.SetConfig(config)
+			return nil, nerrors.Wrap(err, "fail to parse custom config")
 		}
+		customConfig.SetConfig(config)
 	}
 
 	// secret config value decrypt
+	keyring := customSecretKeyring(customConfig)
+	config.keyring = keyring
+	if _, ok := customConfig.(SecretsConfig); ok && keyring != nil {
+		legacySecretKeyring.Store(keyring)
+	}
+	if err := decryptSecretFields(keyring, config, customConfig); err != nil {
+		return nil, nerrors.Wrap(err, "fail to decrypt secrets")
+	}
 	if secretsConfig, ok := customConfig.(SecretsConfig); ok {
-		secretKey = secretsConfig.SecretKey()
-		if err := config.decryptSecrets(); err != nil {
-			log.Fatal(err)
-		}
 		if err := secretsConfig.DecryptSecrets(); err != nil {
-			log.Fatal()
+			return nil, nerrors.Wrap(err, "fail to decrypt custom secrets")
 		}
 	}
 
-	return config
+	return config, nil
+}
+
+// validateConfig - validates the config and the custom config.
+func validateConfig(config *Config, customConfig CustomConfig) error {
+	err := config.Validate()
+	if validator, ok := customConfig.(CustomConfigValidator); ok {
+		err = nerrors.Append(err, validator.ValidateCustomConfig())
+	}
+	return err
+}
+
+// LoadConfig - loads the config file with its includes and profile overlay, then overrides it by the NFGO_ env vars,
+// see NewConfig and NewEnvSource.
+func LoadConfig(confPath string) (*Config, error) {
+	return LoadConfigCustom(confPath, nil)
+}
+
+// LoadConfigCustom - see LoadConfig.
+func LoadConfigCustom(confPath string, customConfig CustomConfig) (*Config, error) {
+	return NewConfigFromSources(customConfig, NewFileSource(confPath), NewEnvSource(SchemaOption(customConfig)))
 }
 
 // MustLoadConfig -
@@ -63,23 +151,20 @@ func MustLoadConfig(confPath string) *Config {
 	return MustLoadConfigCustom(confPath, nil)
 }
 
-// MustLoadConfigCustom  -
-func MustLoadConfigCustom(confPath string, customConfig interface{ SetConfig(config *Config) }) *Config {
-	if confPath == "" {
-		confPath = "app.yaml"
-	}
+// MustLoadConfigCustom  - the problems found by Validate are logged rather than fatal, see MustNewConfigCustom.
+// Migrate to LoadConfigCustom to fail on them.
+func MustLoadConfigCustom(confPath string, customConfig CustomConfig) *Config {
+	return mustNewConfigLenient("fail to load config: ", customConfig,
+		NewFileSource(confPath), NewEnvSource(SchemaOption(customConfig)))
+}
 
+func readConfigFile(confPath string) ([]byte, error) {
 	file, err := os.Open(confPath)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-
-	data, err := io.ReadAll(file)
 	//nolint:errcheck
-	file.Close()
-	if err != nil {
-		log.Fatal(err)
-	}
+	defer file.Close()
 
-	return MustNewConfigCustom(data, customConfig)
+	return io.ReadAll(file)
 }
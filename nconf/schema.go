@@ -0,0 +1,136 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	durationPattern = `^[-+]?([0-9]*(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$`
+)
+
+var (
+	durationType      = reflect.TypeOf(time.Duration(0))
+	timeType          = reflect.TypeOf(time.Time{})
+	configType        = reflect.TypeOf(Config{})
+	yamlUnmarshalType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+)
+
+// JSONSchema - generates the JSON Schema of the config files by the yaml names of the fields, so that the editors
+// can complete and validate app.yaml. The fields of the custom config are merged into the root,
+// without a custom config the root allows any other key.
+func JSONSchema(customConfig CustomConfig) ([]byte, error) {
+	g := &schemaGenerator{visiting: map[reflect.Type]bool{}}
+	root := g.schema(configType)
+	props := root["properties"].(map[string]interface{})
+	props[includeKey] = map[string]interface{}{
+		"description": "the files merged before this one, relative to this file",
+		"type":        []string{"string", "array"},
+		"items":       map[string]interface{}{"type": "string"},
+	}
+	if customConfig != nil {
+		custom := g.schema(reflect.TypeOf(customConfig))
+		if customProps, ok := custom["properties"].(map[string]interface{}); ok {
+			for name, prop := range customProps {
+				if _, ok := props[name]; !ok {
+					props[name] = prop
+				}
+			}
+		}
+	} else {
+		delete(root, "additionalProperties")
+	}
+	root["$schema"] = jsonSchemaDraft
+	root["title"] = "nfgo config"
+	return json.MarshalIndent(root, "", "  ")
+}
+
+type schemaGenerator struct {
+	visiting map[reflect.Type]bool
+}
+
+func (g *schemaGenerator) schema(typ reflect.Type) map[string]interface{} {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch {
+	case typ == durationType:
+		return map[string]interface{}{
+			"type":        []string{"string", "integer"},
+			"pattern":     durationPattern,
+			"description": "a duration such as 300ms, 5s or 1h30m, or an integer of nanoseconds",
+		}
+	case typ == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case reflect.PointerTo(typ).Implements(yamlUnmarshalType):
+		// decodes itself, the shape is unknown
+		return map[string]interface{}{}
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.schema(typ.Elem())}
+	case reflect.Map:
+		// a null value removes the key when the files are merged
+		return map[string]interface{}{"type": "object", "additionalProperties": nullable(g.schema(typ.Elem()))}
+	case reflect.Struct:
+		if g.visiting[typ] {
+			return map[string]interface{}{}
+		}
+		g.visiting[typ] = true
+		defer delete(g.visiting, typ)
+
+		props := map[string]interface{}{}
+		for _, field := range structFields(typ) {
+			fieldType := field.typ
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			// the Config embedded in a custom config is set by SetConfig
+			if fieldType == configType {
+				continue
+			}
+			props[field.name] = g.schema(field.typ)
+		}
+		return map[string]interface{}{"type": "object", "properties": props, "additionalProperties": false}
+	}
+	return map[string]interface{}{}
+}
+
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	switch t := schema["type"].(type) {
+	case string:
+		schema["type"] = []string{t, "null"}
+	case []string:
+		schema["type"] = append(t, "null")
+	}
+	return schema
+}
@@ -1,94 +1,311 @@
 package nconf
 
 import (
+	"encoding/base64"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/nf-go/nfgo/nerrors"
 	"github.com/nf-go/nfgo/nutil/ncrypto"
-	"github.com/nf-go/nfgo/nutil/ntypes"
 )
 
-var secretKey string
-
 const (
 	encEncryptedTextPrefix = "SECRET("
 	encEncryptedTextSuffix = ")"
+
+	// SecretTag - the struct tag which marks a string field as a secret, e.g. `yaml:"password" secret:"true"`,
+	// the SECRET(...) value of the field is decrypted when the config is built.
+	SecretTag = "secret"
 )
 
+// legacySecretKeyring - the keyring of the last SecretsConfig, only for DecryptSecretValue.
+var legacySecretKeyring atomic.Pointer[Keyring]
+
+// SecretsConfig - a custom config which provides a single secret key,
+// its DecryptSecrets is called after the tagged fields are decrypted.
 type SecretsConfig interface {
 	SecretKey() string
 	DecryptSecrets() error
 }
 
-func DecryptSecretValue(value string) (string, error) {
-	if secretKey == "" {
-		return "", nerrors.New("secretKey is not set")
+// SecretKeyringConfig - a custom config which provides the keyring of the secret keys.
+type SecretKeyringConfig interface {
+	SecretKeyring() *Keyring
+}
+
+// Keyring - the secret keys by their ids. The current key encrypts the new secrets as SECRET(kid:ciphertext),
+// and the other keys still decrypt the secrets written with them, so the keys can be rotated.
+type Keyring struct {
+	currentKeyID string
+	keys         map[string]string
+	keyIDs       []string
+}
+
+// NewKeyring - keys maps the key ids to the base64 encoded aes keys.
+// The key id "" is the key of the legacy SECRET(ciphertext) values.
+func NewKeyring(currentKeyID string, keys map[string]string) (*Keyring, error) {
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, nerrors.Errorf("current secret key %q is not in the keyring", currentKeyID)
 	}
-	if strings.HasPrefix(value, encEncryptedTextPrefix) && strings.HasSuffix(encEncryptedTextSuffix, ")") {
-		value = value[len(encEncryptedTextPrefix) : len(value)-len(encEncryptedTextSuffix)]
-		return ncrypto.AESDecryptString(value, secretKey)
+	k := &Keyring{
+		currentKeyID: currentKeyID,
+		keys:         make(map[string]string, len(keys)),
 	}
-	return value, nil
+	for keyID, key := range keys {
+		if strings.ContainsAny(keyID, ":)") {
+			return nil, nerrors.Errorf("secret key id %q must not contain ':' or ')'", keyID)
+		}
+		raw, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, nerrors.Errorf("secret key %q is not base64 encoded", keyID)
+		}
+		if n := len(raw); n != 16 && n != 24 && n != 32 {
+			return nil, nerrors.Errorf("secret key %q is %d bytes rather than 16, 24 or 32", keyID, n)
+		}
+		k.keys[keyID] = key
+		if keyID != currentKeyID {
+			k.keyIDs = append(k.keyIDs, keyID)
+		}
+	}
+	sort.Strings(k.keyIDs)
+	k.keyIDs = append([]string{currentKeyID}, k.keyIDs...)
+	return k, nil
 }
 
-func (conf *Config) decryptSecrets() error {
-	configs := []interface{ decryptSecrets() error }{
-		conf.App,
-		conf.DB,
-		conf.Redis,
-		conf.Web,
-		conf.RPC,
-		conf.CronConfig,
-		conf.Metrics,
+// MustNewKeyring -
+func MustNewKeyring(currentKeyID string, keys map[string]string) *Keyring {
+	k, err := NewKeyring(currentKeyID, keys)
+	if err != nil {
+		log.Fatal("fail to new keyring: ", err)
 	}
-	for _, c := range configs {
-		if ntypes.IsNotNil(c) {
-			if err := c.decryptSecrets(); err != nil {
-				return err
-			}
+	return k
+}
+
+// CurrentKeyID -
+func (k *Keyring) CurrentKeyID() string {
+	return k.currentKeyID
+}
+
+// Encrypt - encrypts the plain text with the current key into SECRET(kid:ciphertext).
+func (k *Keyring) Encrypt(plainText string) (string, error) {
+	encryptedText, err := ncrypto.AESEncryptString(plainText, k.keys[k.currentKeyID])
+	if err != nil {
+		return "", err
+	}
+	if k.currentKeyID != "" {
+		encryptedText = k.currentKeyID + ":" + encryptedText
+	}
+	return encEncryptedTextPrefix + encryptedText + encEncryptedTextSuffix, nil
+}
+
+// Decrypt - decrypts SECRET(kid:ciphertext) with the key of kid, and SECRET(ciphertext) with the key ""
+// or else any key which fits, other values are returned as they are.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsSecretValue(value) {
+		return value, nil
+	}
+	value = value[len(encEncryptedTextPrefix) : len(value)-len(encEncryptedTextSuffix)]
+	if keyID, encryptedText, ok := strings.Cut(value, ":"); ok {
+		key, ok := k.keys[keyID]
+		if !ok {
+			return "", nerrors.Errorf("secret key %q is not in the keyring", keyID)
 		}
+		return ncrypto.AESDecryptString(encryptedText, key)
 	}
-	return nil
+	if key, ok := k.keys[""]; ok {
+		return ncrypto.AESDecryptString(value, key)
+	}
+	var err error
+	for _, keyID := range k.keyIDs {
+		plainText, e := ncrypto.AESDecryptString(value, k.keys[keyID])
+		if e == nil {
+			return plainText, nil
+		}
+		err = e
+	}
+	return "", nerrors.Wrap(err, "no secret key fits")
 }
 
-func (conf *AppConfig) decryptSecrets() error {
-	return nil
+// IsSecretValue - whether the value is in the form of SECRET(...).
+func IsSecretValue(value string) bool {
+	return strings.HasPrefix(value, encEncryptedTextPrefix) && strings.HasSuffix(value, encEncryptedTextSuffix)
 }
 
-func (conf *LogConfig) decryptSecrets() error {
-	return nil
+// DecryptSecretValue - decrypts the value with the secret key of the last built config whose custom config is
+// a SecretsConfig, so that its DecryptSecrets keeps working.
+//
+// Deprecated: the key is shared by all the configs of the process, use Config.Keyring().Decrypt instead.
+func DecryptSecretValue(value string) (string, error) {
+	keyring := legacySecretKeyring.Load()
+	if keyring == nil {
+		return "", nerrors.New("secret keyring is not set")
+	}
+	return keyring.Decrypt(value)
 }
 
-func (conf *DbConfig) decryptSecrets() error {
-	password, err := DecryptSecretValue(conf.Password)
-	if err != nil {
-		return err
+func customSecretKeyring(customConfig CustomConfig) *Keyring {
+	switch c := customConfig.(type) {
+	case SecretKeyringConfig:
+		return c.SecretKeyring()
+	case SecretsConfig:
+		if key := c.SecretKey(); key != "" {
+			return &Keyring{keys: map[string]string{"": key}, keyIDs: []string{""}}
+		}
 	}
-	conf.Password = password
 	return nil
 }
 
-func (conf *RedisConfig) decryptSecrets() error {
-	password, err := DecryptSecretValue(conf.Password)
-	if err != nil {
-		return err
+// decryptSecretFields - decrypts the string fields tagged with secret:"true" in the configs,
+// the structs reachable from several configs are decrypted once. Without a keyring the SECRET(...) values
+// are kept as they are, as the configs without a SecretsConfig always did, while a value which the keyring
+// fails to decrypt is an error.
+func decryptSecretFields(keyring *Keyring, configs ...interface{}) error {
+	if keyring == nil {
+		return nil
 	}
-	conf.Password = password
-	return nil
+	return walkSecretFields(func(path string, value string) (string, error) {
+		if !IsSecretValue(value) {
+			return value, nil
+		}
+		plainText, err := keyring.Decrypt(value)
+		if err != nil {
+			return "", nerrors.Wrapf(err, "fail to decrypt %s", path)
+		}
+		return plainText, nil
+	}, configs...)
 }
 
-func (conf *WebConfig) decryptSecrets() error {
-	return nil
+// RedactedSecret - the mask of the secrets redacted by RedactSecrets.
+const RedactedSecret = "******"
+
+// RedactSecrets - masks the non-empty string fields tagged with secret:"true" in the configs,
+// e.g. before the configs are printed.
+func RedactSecrets(configs ...interface{}) {
+	//nolint:errcheck
+	walkSecretFields(func(path string, value string) (string, error) {
+		if value == "" {
+			return value, nil
+		}
+		return RedactedSecret, nil
+	}, configs...)
 }
 
-func (conf *RPCConfig) decryptSecrets() error {
-	return nil
+func walkSecretFields(fn func(path string, value string) (string, error), configs ...interface{}) error {
+	w := &secretWalker{fn: fn, visited: map[uintptr]bool{}}
+	var err error
+	for _, c := range configs {
+		if c != nil {
+			err = nerrors.Append(err, w.walk(reflect.ValueOf(c), ""))
+		}
+	}
+	return err
 }
 
-func (conf *CronConfig) decryptSecrets() error {
-	return nil
+type secretWalker struct {
+	fn      func(path string, value string) (string, error)
+	visited map[uintptr]bool
 }
 
-func (conf *MetricsConfig) decryptSecrets() error {
+func (w *secretWalker) walk(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || w.visited[v.Pointer()] {
+			return nil
+		}
+		w.visited[v.Pointer()] = true
+		return w.walk(v.Elem(), path)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return w.walk(v.Elem(), path)
+	case reflect.Struct:
+		var err error
+		for _, field := range structFieldValues(v) {
+			fieldPath := joinPath(path, field.name)
+			if field.secret && field.value.Kind() == reflect.String {
+				if field.value.CanSet() {
+					value, e := w.fn(fieldPath, field.value.String())
+					if e != nil {
+						err = nerrors.Append(err, e)
+						continue
+					}
+					field.value.SetString(value)
+				}
+				continue
+			}
+			err = nerrors.Append(err, w.walk(field.value, fieldPath))
+		}
+		return err
+	case reflect.Slice, reflect.Array:
+		var err error
+		for i := 0; i < v.Len(); i++ {
+			err = nerrors.Append(err, w.walk(v.Index(i), path+"["+strconv.Itoa(i)+"]"))
+		}
+		return err
+	case reflect.Map:
+		var err error
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := iter.Value()
+			elemPath := joinPath(path, iter.Key().String())
+			if elem.Kind() == reflect.Struct {
+				// the map elements are not addressable, walks a copy and puts it back
+				copied := reflect.New(elem.Type()).Elem()
+				copied.Set(elem)
+				err = nerrors.Append(err, w.walk(copied, elemPath))
+				v.SetMapIndex(iter.Key(), copied)
+				continue
+			}
+			err = nerrors.Append(err, w.walk(elem, elemPath))
+		}
+		return err
+	}
 	return nil
 }
+
+type structFieldValue struct {
+	name   string
+	secret bool
+	value  reflect.Value
+}
+
+// structFieldValues - returns the exported fields of the struct by their yaml names, the inline fields are flattened.
+func structFieldValues(v reflect.Value) []structFieldValue {
+	var fields []structFieldValue
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") || (field.Anonymous && name == "") {
+			fields = append(fields, structFieldValue{value: v.Field(i)})
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields = append(fields, structFieldValue{
+			name:   name,
+			secret: field.Tag.Get(SecretTag) == "true",
+			value:  v.Field(i),
+		})
+	}
+	return fields
+}
+
+func joinPath(path string, name string) string {
+	if path == "" || name == "" {
+		return path + name
+	}
+	return path + "." + name
+}
@@ -1,14 +1,17 @@
 package nconf
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/nf-go/nfgo/nutil/ncrypto"
+
 	"github.com/stretchr/testify/assert"
 )
 
 func TestDecryptSecretValue(t *testing.T) {
 	a := assert.New(t)
-	secretKey = "Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk="
+	legacySecretKeyring.Store(MustNewKeyring("", map[string]string{"": "Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk="}))
 	encryptedText := "SECRET(gYVa2GgdDYbR6R4AlvB2Tcwi/z9yT20tkiADAsd4yxO3flA1xV0a)"
 
 	plainText, err := DecryptSecretValue(encryptedText)
@@ -20,3 +23,94 @@ func TestDecryptSecretValue(t *testing.T) {
 	a.Nil(err)
 	a.Equal("gYVa2GgdDYbR6R4AlvB2Tcwi/z9yT20tkiADAsd4yxO3flA1xV0a", plainText)
 }
+
+func TestKeyring(t *testing.T) {
+	a := assert.New(t)
+	k1 := "Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk="
+	k2, err := ncrypto.NewAESKeyString(256)
+	a.Nil(err)
+
+	oldKeyring := MustNewKeyring("k1", map[string]string{"k1": k1})
+	oldSecret, err := oldKeyring.Encrypt("hello world")
+	a.Nil(err)
+	a.True(strings.HasPrefix(oldSecret, "SECRET(k1:"))
+
+	keyring := MustNewKeyring("k2", map[string]string{"k1": k1, "k2": k2})
+	newSecret, err := keyring.Encrypt("hello nfgo")
+	a.Nil(err)
+	a.True(strings.HasPrefix(newSecret, "SECRET(k2:"))
+
+	plainText, err := keyring.Decrypt(oldSecret)
+	a.Nil(err)
+	a.Equal("hello world", plainText)
+	plainText, err = keyring.Decrypt(newSecret)
+	a.Nil(err)
+	a.Equal("hello nfgo", plainText)
+	plainText, err = keyring.Decrypt("SECRET(gYVa2GgdDYbR6R4AlvB2Tcwi/z9yT20tkiADAsd4yxO3flA1xV0a)")
+	a.Nil(err)
+	a.Equal("hello world", plainText)
+
+	_, err = oldKeyring.Decrypt(newSecret)
+	a.Contains(err.Error(), `secret key "k2" is not in the keyring`)
+
+	_, err = NewKeyring("k3", map[string]string{"k1": k1})
+	a.NotNil(err)
+	_, err = NewKeyring("k1", map[string]string{"k1": "c2hvcnQ="})
+	a.Contains(err.Error(), "rather than 16, 24 or 32")
+}
+
+type secretConfig struct {
+	*Config
+	keyring *Keyring
+	APIKey  string            `yaml:"apiKey" secret:"true"`
+	Tokens  map[string]string `yaml:"tokens"`
+}
+
+func (c *secretConfig) SetConfig(config *Config) {
+	c.Config = config
+}
+
+func (c *secretConfig) SecretKeyring() *Keyring {
+	return c.keyring
+}
+
+func TestNewConfigCustomSecrets(t *testing.T) {
+	a := assert.New(t)
+	keyring := MustNewKeyring("k1", map[string]string{"k1": "Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk="})
+	dbPassword, _ := keyring.Encrypt("db-pass")
+	apiKey, _ := keyring.Encrypt("api-key")
+	data := []byte(`
+db:
+  host: 127.0.0.1
+  port: 3306
+  database: test
+  username: root
+  password: "` + dbPassword + `"
+apiKey: "` + apiKey + `"
+tokens:
+  foo: "` + apiKey + `"
+`)
+
+	custom := &secretConfig{keyring: keyring}
+	config, err := NewConfigCustom(data, custom)
+	a.Nil(err)
+	a.Equal("db-pass", config.DB.Password)
+	a.Equal("api-key", custom.APIKey)
+	a.Equal(apiKey, custom.Tokens["foo"])
+
+	RedactSecrets(custom)
+	a.Equal(RedactedSecret, config.DB.Password)
+	a.Equal(RedactedSecret, custom.APIKey)
+
+	a.Same(keyring, config.Keyring())
+
+	// the secrets are kept without a keyring
+	config, err = NewConfig(data)
+	a.Nil(err)
+	a.Equal(dbPassword, config.DB.Password)
+	a.Nil(config.Keyring())
+
+	otherKeyring := MustNewKeyring("k2", map[string]string{"k2": "Uv38ByGCZU8WP18PmmIdcpVmx00QA3xNe7sEB9Hixkk="})
+	_, err = NewConfigCustom(data, &secretConfig{keyring: otherKeyring})
+	a.Contains(err.Error(), "fail to decrypt db.password")
+}
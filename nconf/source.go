@@ -0,0 +1,264 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/nf-go/nfgo/nerrors"
+)
+
+// Source - a source of the config values, e.g. a file, the env vars, a directory or a config service.
+//
+// The values are a tree keyed by the yaml names of the config fields. The sources are merged in order,
+// so a later source has a higher priority: its maps are merged key by key, its scalars and lists replace
+// the lower ones, and its nil values remove the keys.
+type Source interface {
+	// Load - loads the values, base is the tree merged from the lower sources and must not be modified,
+	// it lets a source resolve its keys against the existing ones.
+	Load(base map[string]interface{}) (map[string]interface{}, error)
+}
+
+// WatchableSource - a source which pushes its updates.
+type WatchableSource interface {
+	Source
+	// Watch - calls notify whenever the source changes, it blocks until ctx is done.
+	Watch(ctx context.Context, notify func())
+}
+
+type sourceOptions struct {
+	format       Format
+	profile      string
+	pollInterval time.Duration
+	envPrefix    string
+	schema       []reflect.Type
+	httpClient   *http.Client
+	httpHeader   http.Header
+}
+
+// SourceOption -
+type SourceOption func(*sourceOptions)
+
+// FormatOption - sets the format of the bytes source and the http source, the default of the bytes source is yaml
+// and the http source detects it by the content type. The files are always detected by their extensions.
+func FormatOption(format Format) SourceOption {
+	return func(opts *sourceOptions) {
+		opts.format = format
+	}
+}
+
+// ProfileOption - sets the profile of the file source, it takes precedence over NFGO_APP_PROFILE and app.profile.
+func ProfileOption(profile string) SourceOption {
+	return func(opts *sourceOptions) {
+		opts.profile = profile
+	}
+}
+
+// PollIntervalOption - sets how often a watchable source is checked for changes, default is 5s.
+func PollIntervalOption(interval time.Duration) SourceOption {
+	return func(opts *sourceOptions) {
+		opts.pollInterval = interval
+	}
+}
+
+// EnvPrefixOption - sets the prefix of the env vars of the env source, default is NFGO_.
+func EnvPrefixOption(prefix string) SourceOption {
+	return func(opts *sourceOptions) {
+		opts.envPrefix = prefix
+	}
+}
+
+// SchemaOption - sets the custom config whose fields the env and dir sources resolve their names against,
+// without it a custom key is resolved only if a lower source already has it.
+func SchemaOption(customConfig CustomConfig) SourceOption {
+	return func(opts *sourceOptions) {
+		if customConfig != nil {
+			opts.schema = append(opts.schema, reflect.TypeOf(customConfig))
+		}
+	}
+}
+
+// HTTPClientOption - sets the client of the http source, default is a client with 10s timeout.
+func HTTPClientOption(client *http.Client) SourceOption {
+	return func(opts *sourceOptions) {
+		opts.httpClient = client
+	}
+}
+
+// HTTPHeaderOption - adds a header to the requests of the http source, e.g. Authorization.
+func HTTPHeaderOption(key, value string) SourceOption {
+	return func(opts *sourceOptions) {
+		opts.httpHeader.Add(key, value)
+	}
+}
+
+func newSourceOptions(opt []SourceOption) *sourceOptions {
+	opts := &sourceOptions{
+		pollInterval: 5 * time.Second,
+		envPrefix:    EnvOverridePrefix,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+		httpHeader:   http.Header{},
+	}
+	for _, o := range opt {
+		o(opts)
+	}
+	return opts
+}
+
+// NewConfigFromSources - merges the sources in order, then builds the config as NewConfig does.
+func NewConfigFromSources(customConfig CustomConfig, sources ...Source) (*Config, error) {
+	tree, err := loadSources(sources)
+	if err != nil {
+		return nil, err
+	}
+	return buildConfig(tree, customConfig)
+}
+
+// MustNewConfigFromSources -
+func MustNewConfigFromSources(customConfig CustomConfig, sources ...Source) *Config {
+	config, err := NewConfigFromSources(customConfig, sources...)
+	if err != nil {
+		log.Fatal("fail to new config: ", err)
+	}
+	return config
+}
+
+// NewBytesSource - a source of the yaml bytes or the bytes of FormatOption, the env placeholders are expanded.
+func NewBytesSource(data []byte, opt ...SourceOption) Source {
+	return &bytesSource{
+		data: data,
+		opts: newSourceOptions(opt),
+	}
+}
+
+type bytesSource struct {
+	data []byte
+	opts *sourceOptions
+}
+
+func (s *bytesSource) Load(base map[string]interface{}) (map[string]interface{}, error) {
+	tree, err := parseTree(s.data, s.opts.format)
+	if err != nil {
+		return nil, nerrors.Wrap(err, "fail to parse config")
+	}
+	if err := expandEnvPlaceholders(tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func (s *bytesSource) String() string {
+	return "bytes"
+}
+
+func loadSources(sources []Source) (map[string]interface{}, error) {
+	tree := map[string]interface{}{}
+	for _, src := range sources {
+		values, err := src.Load(tree)
+		if err != nil {
+			return nil, nerrors.Wrapf(err, "fail to load config from %v", src)
+		}
+		mergeTree(tree, values)
+	}
+	pruneTree(tree)
+	return tree, nil
+}
+
+// pollSource - reads the source every interval and calls notify when the digest of the data changes.
+// The first read always notifies, since the source may have changed after it was loaded.
+func pollSource(ctx context.Context, interval time.Duration, src Source, read func() ([]byte, error), notify func()) {
+	var last []byte
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			data, err := read()
+			if err != nil {
+				log.Printf("nconf: fail to poll %v: %s", src, err)
+				continue
+			}
+			digest := sha256.Sum256(data)
+			if !bytes.Equal(last, digest[:]) {
+				last = digest[:]
+				notify()
+			}
+		}
+	}
+}
+
+// normalizeTree - converts the map[interface{}]interface{} of yaml to map[string]interface{} recursively.
+func normalizeTree(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for key, val := range t {
+			m[fmt.Sprint(key)] = normalizeTree(val)
+		}
+		return m
+	case map[string]interface{}:
+		for key, val := range t {
+			t[key] = normalizeTree(val)
+		}
+		return t
+	case []interface{}:
+		for i, val := range t {
+			t[i] = normalizeTree(val)
+		}
+		return t
+	}
+	return v
+}
+
+// mergeTree - deep-merges src into dst, maps are merged recursively and other values are replaced.
+// The nil values are kept as tombstones so that they still remove the keys when the tree is merged into
+// another one, use pruneTree to drop them at last.
+func mergeTree(dst, src map[string]interface{}) {
+	for key, srcVal := range src {
+		srcMap, srcIsMap := srcVal.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeTree(dstMap, srcMap)
+			continue
+		}
+		if srcIsMap {
+			dstMap = map[string]interface{}{}
+			mergeTree(dstMap, srcMap)
+			srcVal = dstMap
+		}
+		dst[key] = srcVal
+	}
+}
+
+// pruneTree - removes the keys whose values are nil.
+func pruneTree(tree map[string]interface{}) {
+	for key, val := range tree {
+		if val == nil {
+			delete(tree, key)
+		} else if m, ok := val.(map[string]interface{}); ok {
+			pruneTree(m)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/nf-go/nfgo/nerrors"
+)
+
+// NewDirSource - a source of the files in a directory, such as a mounted Kubernetes ConfigMap or Secret.
+//
+// A *.yaml, *.yml, *.json or *.toml file is merged as a whole. Any other file holds one value, the name of the file is either
+// a dotted key such as db.password, or a name like DB_PASSWORD which is resolved as the env vars are.
+// The hidden files, e.g. the ..data links of Kubernetes, are skipped. It is watchable, the directory
+// is polled every PollIntervalOption.
+func NewDirSource(dir string, opt ...SourceOption) Source {
+	return &dirSource{
+		dir:  dir,
+		opts: newSourceOptions(opt),
+	}
+}
+
+type dirSource struct {
+	dir  string
+	opts *sourceOptions
+}
+
+func (s *dirSource) Load(base map[string]interface{}) (map[string]interface{}, error) {
+	files, err := s.readFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	tree := map[string]interface{}{}
+	for _, f := range files {
+		format, ok := formatOfExt(f.name)
+		if !ok {
+			continue
+		}
+		fileTree, err := parseTree(f.data, format)
+		if err != nil {
+			return nil, nerrors.Wrapf(err, "fail to parse %s", f.name)
+		}
+		if err := expandEnvPlaceholders(fileTree); err != nil {
+			return nil, err
+		}
+		mergeTree(tree, fileTree)
+	}
+
+	view := map[string]interface{}{}
+	mergeTree(view, base)
+	mergeTree(view, tree)
+	types := append([]reflect.Type{reflect.TypeOf(Config{})}, s.opts.schema...)
+	for _, f := range files {
+		if _, ok := formatOfExt(f.name); ok {
+			continue
+		}
+		var segs []string
+		dotted := strings.Contains(f.name, ".")
+		if dotted {
+			segs = strings.Split(f.name, ".")
+		} else {
+			segs = strings.FieldsFunc(f.name, func(r rune) bool {
+				return r == '_' || r == '-'
+			})
+		}
+		path, kind, ok := resolveKeyPath(types, view, segs)
+		if !ok {
+			if !dotted {
+				continue
+			}
+			path, kind = segs, reflect.Interface
+		}
+		setTreeValue(tree, path, parseEnvValue(strings.TrimRight(string(f.data), "\r\n"), kind))
+	}
+	return tree, nil
+}
+
+func (s *dirSource) Watch(ctx context.Context, notify func()) {
+	pollSource(ctx, s.opts.pollInterval, s, func() ([]byte, error) {
+		files, err := s.readFiles()
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		for _, f := range files {
+			buf.WriteString(f.name)
+			buf.WriteByte(0)
+			buf.Write(f.data)
+			buf.WriteByte(0)
+		}
+		return buf.Bytes(), nil
+	}, notify)
+}
+
+func (s *dirSource) String() string {
+	return "dir " + s.dir
+}
+
+type dirFile struct {
+	name string
+	data []byte
+}
+
+func (s *dirSource) readFiles() ([]dirFile, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	// os.ReadDir sorts the entries by name
+	var files []dirFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		path := filepath.Join(s.dir, name)
+		// follows the symlinks, the files of a Kubernetes volume are links to the ..data directory
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, dirFile{name: name, data: data})
+	}
+	return files, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/nf-go/nfgo/nerrors"
+)
+
+// NewHTTPSource - a source of a config service which serves the config on GET url, in json by default,
+// or in yaml or toml by the content type or FormatOption.
+// It is watchable, the url is polled every PollIntervalOption.
+func NewHTTPSource(url string, opt ...SourceOption) Source {
+	return &httpSource{
+		url:  url,
+		opts: newSourceOptions(opt),
+	}
+}
+
+type httpSource struct {
+	url  string
+	opts *sourceOptions
+}
+
+func (s *httpSource) Load(base map[string]interface{}) (map[string]interface{}, error) {
+	data, contentType, err := s.fetch(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	format := s.opts.format
+	if format == "" {
+		format = formatOfContentType(contentType)
+	}
+	return parseTree(data, format)
+}
+
+func (s *httpSource) Watch(ctx context.Context, notify func()) {
+	pollSource(ctx, s.opts.pollInterval, s, func() ([]byte, error) {
+		data, _, err := s.fetch(ctx)
+		return data, err
+	}, notify)
+}
+
+func (s *httpSource) String() string {
+	return "http " + s.url
+}
+
+func (s *httpSource) fetch(ctx context.Context) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	for key, values := range s.opts.httpHeader {
+		req.Header[key] = values
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json, application/yaml, application/toml")
+	}
+
+	resp, err := s.opts.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	//nolint:errcheck
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", nerrors.Errorf("unexpected status %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
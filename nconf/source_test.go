@@ -0,0 +1,92 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirSource(t *testing.T) {
+	a := assert.New(t)
+	confDir := writeConfigFiles(t, map[string]string{
+		"app.yaml": "app:\n  name: foo\ndb:\n  host: 127.0.0.1\n  port: 3306\n  database: test\n",
+	})
+	secretDir := writeConfigFiles(t, map[string]string{
+		"db.username":   "root\n",
+		"DB_PASSWORD":   "s3cret\n",
+		"DB_MAX_IDLE":   "8",
+		"app.ext.token": "t1",
+		".hidden":       "ignored",
+	})
+
+	config, err := NewConfigFromSources(nil,
+		NewFileSource(filepath.Join(confDir, "app.yaml")),
+		NewDirSource(secretDir),
+	)
+	a.Nil(err)
+	a.Equal("foo", config.App.Name)
+	a.Equal("root", config.DB.Username)
+	a.Equal("s3cret", config.DB.Password)
+	a.Equal(int32(8), config.DB.MaxIdle)
+	a.Equal("t1", config.App.Ext["token"])
+}
+
+func TestHTTPSource(t *testing.T) {
+	a := assert.New(t)
+	var level atomic.Value
+	level.Store("info")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t0ken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"log": {"level": "` + level.Load().(string) + `"}, "web": {"port": 8081}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewConfigFromSources(nil, NewHTTPSource(srv.URL))
+	a.Contains(err.Error(), "unexpected status 401")
+
+	w, err := NewSourcesConfigWatcher([]Source{
+		NewBytesSource([]byte("log:\n  level: warn\n  format: text\nweb:\n  port: 8080\n")),
+		NewHTTPSource(srv.URL, HTTPHeaderOption("Authorization", "Bearer t0ken"), PollIntervalOption(10*time.Millisecond)),
+	})
+	a.Nil(err)
+	//nolint:errcheck
+	defer w.Close()
+	a.Equal("info", w.Config().Log.Level)
+	a.Equal("text", w.Config().Log.Format)
+	a.Equal(int32(8081), w.Config().Web.Port)
+
+	changed := make(chan *Config, 1)
+	w.OnChange(func(old, new *Config) {
+		changed <- new
+	})
+	level.Store("debug")
+	select {
+	case config := <-changed:
+		a.Equal("debug", config.Log.Level)
+		a.Equal("text", config.Log.Format)
+	case <-time.After(5 * time.Second):
+		a.Fail("the config is not reloaded")
+	}
+}
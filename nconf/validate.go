@@ -0,0 +1,357 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/nf-go/nfgo/nutil/ntypes"
+	"github.com/robfig/cron/v3"
+)
+
+// Validate - validates all the sections of the config,
+// every problem is collected into one error which can be split by nerrors.Errors.
+func (conf *Config) Validate() error {
+	configs := []interface{ Validate() error }{
+		conf.App,
+		conf.Log,
+		conf.DB,
+		conf.Redis,
+		conf.Web,
+		conf.RPC,
+		conf.CronConfig,
+		conf.Metrics,
+		conf.Features,
+		conf.Trace,
+	}
+	var err error
+	for _, c := range configs {
+		if ntypes.IsNotNil(c) {
+			err = nerrors.Append(err, c.Validate())
+		}
+	}
+	return err
+}
+
+// Validate -
+func (conf *AppConfig) Validate() error {
+	var err error
+	if conf.GOMAXPROCS < 0 {
+		err = nerrors.Append(err, nerrors.Errorf("app.goMaxProcs %d must not be negative", conf.GOMAXPROCS))
+	}
+	if conf.GraceTermination != nil && conf.GraceTermination.GraceTerminationPeriod < 0 {
+		err = nerrors.Append(err, nerrors.Errorf("app.graceTermination.graceTerminationPeriod %s must not be negative",
+			conf.GraceTermination.GraceTerminationPeriod))
+	}
+	return err
+}
+
+// Validate -
+func (conf *LogConfig) Validate() error {
+	err := nerrors.Combine(
+		validateLogLevel("log.level", conf.Level),
+		validateLogFormat("log.format", conf.Format),
+		validateLogRotation("log.rotation", conf.Rotation),
+	)
+	for name, level := range conf.Levels {
+		err = nerrors.Append(err, validateLogLevel("log.levels."+name, level))
+	}
+	for i, rule := range conf.Redaction {
+		err = nerrors.Append(err, validateLogRedactionRule(fmt.Sprintf("log.redaction[%d]", i), rule))
+	}
+	if conf.TraceDebug && conf.TraceDebugToken == "" {
+		err = nerrors.Append(err, nerrors.New("log.traceDebugToken is required by log.traceDebug"))
+	}
+	if conf.Async != nil {
+		switch conf.Async.Overflow {
+		case "", LogOverflowBlock, LogOverflowDropDebug, LogOverflowDropAll:
+		default:
+			err = nerrors.Append(err, nerrors.Errorf("log.async.overflow %q is not one of block, dropDebug, dropAll", conf.Async.Overflow))
+		}
+		err = nerrors.Combine(err,
+			validateNotNegative("log.async.queueSize", int64(conf.Async.QueueSize)),
+			validateNotNegative("log.async.flushInterval", int64(conf.Async.FlushInterval)),
+		)
+	}
+	if conf.Sampling != nil {
+		err = nerrors.Combine(err,
+			validateNotNegative("log.sampling.initial", int64(conf.Sampling.Initial)),
+			validateNotNegative("log.sampling.thereafter", int64(conf.Sampling.Thereafter)),
+		)
+	}
+	if conf.ErrorStack != nil {
+		err = nerrors.Combine(err,
+			validateLogLevel("log.errorStack.level", conf.ErrorStack.Level),
+			validateNotNegative("log.errorStack.maxFrames", int64(conf.ErrorStack.MaxFrames)),
+		)
+	}
+	for i, sink := range conf.Sinks {
+		name := fmt.Sprintf("log.sinks[%d]", i)
+		if sink == nil {
+			err = nerrors.Append(err, nerrors.Errorf("%s is empty", name))
+			continue
+		}
+		err = nerrors.Combine(err,
+			validateLogLevel(name+".level", sink.Level),
+			validateLogFormat(name+".format", sink.Format),
+			validateLogRotation(name+".rotation", sink.Rotation),
+		)
+		switch sink.Type {
+		case LogSinkStderr, LogSinkStdout:
+		case LogSinkFile:
+			err = nerrors.Append(err, validateRequired(name+".logPath", sink.LogPath))
+		case LogSinkTCP, LogSinkUDP:
+			err = nerrors.Append(err, validateRequired(name+".addr", sink.Addr))
+		default:
+			err = nerrors.Append(err, nerrors.Errorf("%s.type %q is not one of stderr, stdout, file, tcp, udp", name, sink.Type))
+		}
+	}
+	return err
+}
+
+func validateLogLevel(name string, level string) error {
+	switch strings.ToLower(level) {
+	case "", "debug", "info", "warn", "error", "panic", "fatal":
+		return nil
+	}
+	return nerrors.Errorf("%s %q is not one of debug, info, warn, error, panic, fatal", name, level)
+}
+
+func validateLogFormat(name string, format string) error {
+	switch format {
+	case "", "json", "text":
+		return nil
+	}
+	return nerrors.Errorf("%s %q is not one of json, text", name, format)
+}
+
+func validateLogRotation(name string, conf *LogRotationConfig) error {
+	if conf == nil {
+		return nil
+	}
+	return nerrors.Combine(
+		validateNotNegative(name+".maxSize", int64(conf.MaxSize)),
+		validateNotNegative(name+".maxAge", int64(conf.MaxAge)),
+		validateNotNegative(name+".maxBackups", int64(conf.MaxBackups)),
+	)
+}
+
+func validateLogRedactionRule(name string, rule *LogRedactionRule) error {
+	if rule == nil {
+		return nerrors.Errorf("%s is empty", name)
+	}
+	var err error
+	if len(rule.Fields) == 0 {
+		err = nerrors.Append(err, nerrors.Errorf("%s.fields is required", name))
+	}
+	for _, field := range rule.Fields {
+		if _, e := path.Match(field, ""); e != nil {
+			err = nerrors.Append(err, nerrors.Errorf("%s.fields %q is invalid: %s", name, field, e))
+		}
+	}
+	switch rule.Strategy {
+	case "", LogRedactMask, LogRedactPartial, LogRedactHash, LogRedactRemove:
+	default:
+		err = nerrors.Append(err, nerrors.Errorf("%s.strategy %q is not one of mask, partial, hash, remove", name, rule.Strategy))
+	}
+	if rule.Strategy == LogRedactHash && rule.HashKey == "" {
+		err = nerrors.Append(err, nerrors.Errorf("%s.hashKey is required by the hash strategy", name))
+	}
+	return nerrors.Combine(err,
+		validateNotNegative(name+".keepPrefix", int64(rule.KeepPrefix)),
+		validateNotNegative(name+".keepSuffix", int64(rule.KeepSuffix)),
+	)
+}
+
+// Validate -
+func (conf *DbConfig) Validate() error {
+	return nerrors.Combine(
+		validateRequired("db.host", conf.Host),
+		validatePort("db.port", conf.Port),
+		validateRequired("db.database", conf.Database),
+		validateRequired("db.username", conf.Username),
+		validateNotNegative("db.maxIdle", int64(conf.MaxIdle)),
+		validateNotNegative("db.maxOpen", int64(conf.MaxOpen)),
+	)
+}
+
+// Validate -
+func (conf *RedisConfig) Validate() error {
+	var err error
+	switch {
+	case conf.Sentinel != nil && conf.Cluster != nil:
+		err = nerrors.Append(err, nerrors.New("redis.sentinel and redis.cluster are mutually exclusive"))
+	case conf.Sentinel != nil:
+		err = nerrors.Append(err, validateRequired("redis.sentinel.master", conf.Sentinel.Master))
+		if len(conf.Sentinel.Addrs) == 0 {
+			err = nerrors.Append(err, nerrors.New("redis.sentinel.addrs is required"))
+		}
+	case conf.Cluster != nil:
+		if len(conf.Cluster.Addrs) == 0 {
+			err = nerrors.Append(err, nerrors.New("redis.cluster.addrs is required"))
+		}
+	default:
+		err = nerrors.Append(err, validateRequired("redis.host", conf.Host))
+		err = nerrors.Append(err, validatePort("redis.port", conf.Port))
+	}
+	return nerrors.Combine(err,
+		validateNotNegative("redis.maxIdle", int64(conf.MaxIdle)),
+		validateNotNegative("redis.maxActive", int64(conf.MaxActive)),
+	)
+}
+
+// Validate -
+func (conf *WebConfig) Validate() error {
+	err := nerrors.Combine(
+		validatePort("web.port", conf.Port),
+		validateNotNegative("web.maxMultipartMemory", conf.MaxMultipartMemory),
+		validateNotNegativeDuration("web.timeout", conf.Timeout),
+	)
+	for route, timeout := range conf.RouteTimeouts {
+		err = nerrors.Append(err, validateNotNegativeDuration("web.routeTimeouts."+route, timeout))
+	}
+	if conf.Swagger != nil && conf.Swagger.URL != "" {
+		if _, e := url.Parse(conf.Swagger.URL); e != nil {
+			err = nerrors.Append(err, nerrors.Errorf("web.swagger.url is invalid: %s", e))
+		}
+	}
+	return err
+}
+
+// Validate -
+func (conf *RPCConfig) Validate() error {
+	err := nerrors.Combine(
+		validatePort("rpc.port", conf.Port),
+		validateNotNegative("rpc.maxRecvMsgSize", conf.MaxRecvMsgSize),
+		validateNotNegativeDuration("rpc.timeout", conf.Timeout),
+	)
+	for method, timeout := range conf.MethodTimeouts {
+		err = nerrors.Append(err, validateNotNegativeDuration("rpc.methodTimeouts."+method, timeout))
+	}
+	for name, clientConf := range conf.Clients {
+		if clientConf == nil {
+			err = nerrors.Append(err, nerrors.Errorf("rpc.clients.%s is empty", name))
+			continue
+		}
+		err = nerrors.Append(err, validateRequired("rpc.clients."+name+".addr", clientConf.Addr))
+		err = nerrors.Append(err, validateNotNegativeDuration("rpc.clients."+name+".timeout", clientConf.Timeout))
+	}
+	return err
+}
+
+// Validate -
+func (conf *MetricsConfig) Validate() error {
+	err := validatePort("metrics.port", conf.Port)
+	if !strings.HasPrefix(conf.MetricsPath, "/") {
+		err = nerrors.Append(err, nerrors.Errorf("metrics.metricsPath %q must start with /", conf.MetricsPath))
+	}
+	return err
+}
+
+// Validate -
+func (conf *CronConfig) Validate() error {
+	err := validateNotNegativeDuration("cron.timeout", conf.Timeout)
+	names := map[string]struct{}{}
+	for i, job := range conf.CronJobs {
+		if job == nil {
+			err = nerrors.Append(err, nerrors.Errorf("cron.cronJobs[%d] is empty", i))
+			continue
+		}
+		if job.Name == "" {
+			err = nerrors.Append(err, nerrors.Errorf("cron.cronJobs[%d].name is required", i))
+		} else if _, ok := names[job.Name]; ok {
+			err = nerrors.Append(err, nerrors.Errorf("cron.cronJobs[%d].name %s is duplicated", i, job.Name))
+		}
+		names[job.Name] = struct{}{}
+		if _, e := cron.ParseStandard(job.Schedule); e != nil {
+			err = nerrors.Append(err, nerrors.Errorf("cron.cronJobs[%d].schedule %q is invalid: %s", i, job.Schedule, e))
+		}
+		err = nerrors.Append(err, validateNotNegativeDuration(fmt.Sprintf("cron.cronJobs[%d].timeout", i), job.Timeout))
+	}
+	return err
+}
+
+// Validate -
+func (conf FeaturesConfig) Validate() error {
+	var err error
+	for name, feature := range conf {
+		if feature != nil && feature.Percentage != nil && (*feature.Percentage < 0 || *feature.Percentage > 100) {
+			err = nerrors.Append(err, nerrors.Errorf("features.%s.percentage %v is out of range [0, 100]", name, *feature.Percentage))
+		}
+	}
+	return err
+}
+
+// Validate -
+func (conf *TraceConfig) Validate() error {
+	var err error
+	for i, propagator := range conf.Propagators {
+		switch propagator {
+		case TracePropagatorW3C, TracePropagatorB3:
+		default:
+			err = nerrors.Append(err, nerrors.Errorf("trace.propagators[%d] %q is not one of w3c, b3", i, propagator))
+		}
+	}
+	switch conf.Exporter {
+	case "", TraceExporterOTLP, TraceExporterStdout, TraceExporterMemory:
+	default:
+		err = nerrors.Append(err, nerrors.Errorf("trace.exporter %q is not one of otlp, stdout, memory", conf.Exporter))
+	}
+	if conf.SampleRatio != nil && (*conf.SampleRatio < 0 || *conf.SampleRatio > 1) {
+		err = nerrors.Append(err, nerrors.Errorf("trace.sampleRatio %v is out of range [0, 1]", *conf.SampleRatio))
+	}
+	if baggage := conf.Baggage; baggage != nil {
+		for i, key := range baggage.Keys {
+			if key == "" || strings.ContainsAny(key, " \t,;=\"\\()/:<>?@[]{}") {
+				err = nerrors.Append(err, nerrors.Errorf("trace.baggage.keys[%d] %q is not a valid key", i, key))
+			}
+		}
+		err = nerrors.Append(err, validateNotNegative("trace.baggage.maxBytes", int64(baggage.MaxBytes)))
+	}
+	return err
+}
+
+func validateRequired(name string, value string) error {
+	if value == "" {
+		return nerrors.Errorf("%s is required", name)
+	}
+	return nil
+}
+
+func validatePort(name string, port int32) error {
+	if port <= 0 || port > 65535 {
+		return nerrors.Errorf("%s %d is out of range [1, 65535]", name, port)
+	}
+	return nil
+}
+
+func validateNotNegative(name string, value int64) error {
+	if value < 0 {
+		return nerrors.Errorf("%s %d must not be negative", name, value)
+	}
+	return nil
+}
+
+func validateNotNegativeDuration(name string, value time.Duration) error {
+	if value < 0 {
+		return nerrors.Errorf("%s %s must not be negative", name, value)
+	}
+	return nil
+}
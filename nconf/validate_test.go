@@ -0,0 +1,262 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	a := assert.New(t)
+	_, err := NewConfig([]byte(`
+log:
+  level: verbose
+web:
+  port: 70000
+db:
+  host: 127.0.0.1
+  port: 3306
+redis:
+  sentinel:
+    master: mymaster
+    addrs: ["127.0.0.1:26379"]
+  cluster:
+    addrs: ["127.0.0.1:7000"]
+cron:
+  cronJobs:
+  - name: demoJob
+    schedule: "* * *"
+`))
+	a.NotNil(err)
+	errs := nerrors.Errors(err)
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, e.Error())
+	}
+	a.Len(msgs, 6)
+	a.Contains(msgs, `log.level "verbose" is not one of debug, info, warn, error, panic, fatal`)
+	a.Contains(msgs, "web.port 70000 is out of range [1, 65535]")
+	a.Contains(msgs, "db.database is required")
+	a.Contains(msgs, "db.username is required")
+	a.Contains(msgs, "redis.sentinel and redis.cluster are mutually exclusive")
+	a.Contains(err.Error(), `cron.cronJobs[0].schedule "* * *" is invalid`)
+}
+
+type barConfig struct {
+	*Config
+	Bar string `yaml:"bar"`
+}
+
+func (c *barConfig) SetConfig(config *Config) {
+	c.Config = config
+}
+
+func (c *barConfig) ValidateCustomConfig() error {
+	return validateRequired("bar", c.Bar)
+}
+
+func TestNewConfigCustomValidate(t *testing.T) {
+	a := assert.New(t)
+	_, err := NewConfigCustom([]byte("web:\n  port: -1\n"), &barConfig{})
+	a.Len(nerrors.Errors(err), 2)
+	a.Contains(err.Error(), "web.port -1 is out of range [1, 65535]")
+	a.Contains(err.Error(), "bar is required")
+
+	config, err := NewConfigCustom([]byte("bar: b1\n"), &barConfig{})
+	a.Nil(err)
+	a.NotNil(config)
+
+	_, err = NewConfig([]byte("log: [\n"))
+	a.Contains(err.Error(), "fail to parse config")
+}
+
+func TestFeaturesValidate(t *testing.T) {
+	a := assert.New(t)
+	config, err := NewConfig([]byte("features:\n  newCheckout: true\n  darkMode:\n    percentage: 30\n"))
+	a.Nil(err)
+	a.True(*config.Features["newCheckout"].Enabled)
+	a.Equal(30.0, *config.Features["darkMode"].Percentage)
+
+	_, err = NewConfig([]byte("features:\n  darkMode:\n    percentage: 120\n"))
+	a.Contains(err.Error(), "features.darkMode.percentage 120 is out of range [0, 100]")
+}
+
+func TestLogSinksValidate(t *testing.T) {
+	a := assert.New(t)
+	_, err := NewConfig([]byte(`
+log:
+  sinks:
+  - type: stderr
+    format: text
+  - type: file
+    level: verbose
+  - type: tcp
+  - type: kafka
+`))
+	a.Len(nerrors.Errors(err), 4)
+	a.Contains(err.Error(), `log.sinks[1].level "verbose" is not one of debug, info, warn, error, panic, fatal`)
+	a.Contains(err.Error(), "log.sinks[1].logPath is required")
+	a.Contains(err.Error(), "log.sinks[2].addr is required")
+	a.Contains(err.Error(), `log.sinks[3].type "kafka" is not one of stderr, stdout, file, tcp, udp`)
+}
+
+func TestLogLevelsValidate(t *testing.T) {
+	_, err := NewConfig([]byte("log:\n  levels:\n    ndb: debug\n    web: verbose\n"))
+	assert.Equal(t, `log.levels.web "verbose" is not one of debug, info, warn, error, panic, fatal`, err.Error())
+}
+
+func TestLogRedactionValidate(t *testing.T) {
+	a := assert.New(t)
+	_, err := NewConfig([]byte(`
+log:
+  redaction:
+  - fields: [password, "$.user.idCard"]
+  - strategy: partial
+    fields: [phone]
+    keepPrefix: -1
+  - fields: ["[a-"]
+    strategy: shuffle
+  - fields: [email]
+    strategy: hash
+  sampling:
+    initial: -1
+  async:
+    overflow: dropInfo
+  errorStack:
+    level: verbose
+    maxFrames: -1
+`))
+	a.Len(nerrors.Errors(err), 8)
+	a.Contains(err.Error(), "log.redaction[3].hashKey is required by the hash strategy")
+	a.Contains(err.Error(), `log.errorStack.level "verbose" is not one of debug, info, warn, error, panic, fatal`)
+	a.Contains(err.Error(), "log.errorStack.maxFrames -1 must not be negative")
+	a.Contains(err.Error(), `log.async.overflow "dropInfo" is not one of block, dropDebug, dropAll`)
+	a.Contains(err.Error(), "log.sampling.initial -1 must not be negative")
+	a.Contains(err.Error(), "log.redaction[1].keepPrefix -1 must not be negative")
+	a.Contains(err.Error(), `log.redaction[2].fields "[a-" is invalid: syntax error in pattern`)
+	a.Contains(err.Error(), `log.redaction[2].strategy "shuffle" is not one of mask, partial, hash, remove`)
+}
+
+func TestLogTraceDebugValidate(t *testing.T) {
+	_, err := NewConfig([]byte("log:\n  traceDebug: true\n"))
+	assert.Equal(t, "log.traceDebugToken is required by log.traceDebug", err.Error())
+	_, err = NewConfig([]byte("log:\n  traceDebug: true\n  traceDebugToken: t0ken\n"))
+	assert.Nil(t, err)
+}
+
+func TestTraceValidate(t *testing.T) {
+	a := assert.New(t)
+	config, err := NewConfig([]byte("trace:\n  propagators: [b3, w3c]\n"))
+	a.Nil(err)
+	a.Equal([]string{TracePropagatorB3, TracePropagatorW3C}, config.Trace.Propagators)
+
+	config, err = NewConfig([]byte("app:\n  name: foo\n"))
+	a.Nil(err)
+	a.Equal([]string{TracePropagatorW3C}, config.Trace.Propagators)
+
+	_, err = NewConfig([]byte(`
+trace:
+  propagators: [jaeger]
+  exporter: zipkin
+  sampleRatio: 1.5
+  baggage:
+    keys: [tenantID, "tenant id", ""]
+    maxBytes: -1
+`))
+	a.Len(nerrors.Errors(err), 6)
+	a.Contains(err.Error(), `trace.baggage.keys[1] "tenant id" is not a valid key`)
+	a.Contains(err.Error(), `trace.baggage.keys[2] "" is not a valid key`)
+	a.Contains(err.Error(), "trace.baggage.maxBytes -1 must not be negative")
+	a.Contains(err.Error(), `trace.propagators[0] "jaeger" is not one of w3c, b3`)
+	a.Contains(err.Error(), `trace.exporter "zipkin" is not one of otlp, stdout, memory`)
+	a.Contains(err.Error(), "trace.sampleRatio 1.5 is out of range [0, 1]")
+}
+
+func TestTimeoutValidate(t *testing.T) {
+	a := assert.New(t)
+	config, err := NewConfig([]byte(`
+web:
+  port: 8080
+  timeout: 3s
+  routeTimeouts:
+    "POST /upload": 1m
+    "GET /stream": 0s
+rpc:
+  port: 9090
+  timeout: 2s
+  methodTimeouts:
+    /pkg.Svc/Slow: 10s
+cron:
+  timeout: 1h
+  cronJobs:
+  - name: demoJob
+    schedule: "* * * * *"
+  - name: fastJob
+    schedule: "* * * * *"
+    timeout: 1m
+`))
+	a.Nil(err)
+	a.Equal(3*time.Second, config.Web.RouteTimeout("GET", "/users/:id"))
+	a.Equal(time.Minute, config.Web.RouteTimeout("POST", "/upload"))
+	a.Equal(time.Duration(0), config.Web.RouteTimeout("GET", "/stream"))
+	a.Equal(2*time.Second, config.RPC.MethodTimeout("/pkg.Svc/Fast"))
+	a.Equal(10*time.Second, config.RPC.MethodTimeout("/pkg.Svc/Slow"))
+	a.Equal(time.Hour, config.CronConfig.JobTimeout(config.CronConfig.CronJobs[0]))
+	a.Equal(time.Minute, config.CronConfig.JobTimeout(config.CronConfig.CronJobs[1]))
+
+	_, err = NewConfig([]byte(`
+web:
+  port: 8080
+  timeout: -1s
+  routeTimeouts:
+    "GET /users": -2s
+rpc:
+  port: 9090
+  methodTimeouts:
+    /pkg.Svc/Slow: -3s
+  clients:
+    foo:
+      addr: foo:9090
+      timeout: -4s
+cron:
+  timeout: -5s
+  cronJobs:
+  - name: demoJob
+    schedule: "* * * * *"
+    timeout: -6s
+`))
+	a.Len(nerrors.Errors(err), 6)
+	a.Contains(err.Error(), "web.timeout -1s must not be negative")
+	a.Contains(err.Error(), "web.routeTimeouts.GET /users -2s must not be negative")
+	a.Contains(err.Error(), "rpc.methodTimeouts./pkg.Svc/Slow -3s must not be negative")
+	a.Contains(err.Error(), "rpc.clients.foo.timeout -4s must not be negative")
+	a.Contains(err.Error(), "cron.timeout -5s must not be negative")
+	a.Contains(err.Error(), "cron.cronJobs[0].timeout -6s must not be negative")
+}
+
+func TestMustNewConfigLenient(t *testing.T) {
+	a := assert.New(t)
+	data := []byte("db:\n  host: 127.0.0.1\nredis:\n  host: 127.0.0.1\n")
+	_, err := NewConfig(data)
+	a.Len(nerrors.Errors(err), 2)
+
+	config := MustNewConfig(data)
+	a.Equal(int32(3306), config.DB.Port)
+	a.Equal(int32(6379), config.Redis.Port)
+}
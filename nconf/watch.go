@@ -0,0 +1,226 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"context"
+	"crypto/sha256"
+	"log"
+	"sync"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ConfigWatcher - watches the config sources, and reloads the config when any of them pushes an update.
+type ConfigWatcher interface {
+	// Config - returns the current config.
+	Config() *Config
+	// OnChange - registers a subscriber which is called with the old and the new config after each reload.
+	OnChange(fn func(old, new *Config))
+	// Close - stops watching the config sources.
+	Close() error
+}
+
+type watcherOptions struct {
+	interval        time.Duration
+	newCustomConfig func() CustomConfig
+}
+
+// WatcherOption -
+type WatcherOption func(*watcherOptions)
+
+// WatchIntervalOption - sets how often the config file of NewConfigWatcher is checked for changes, default is 5s.
+func WatchIntervalOption(interval time.Duration) WatcherOption {
+	return func(opts *watcherOptions) {
+		opts.interval = interval
+	}
+}
+
+// CustomConfigOption - sets the factory of the custom config,
+// a new custom config is created and populated on every reload.
+func CustomConfigOption(newCustomConfig func() CustomConfig) WatcherOption {
+	return func(opts *watcherOptions) {
+		opts.newCustomConfig = newCustomConfig
+	}
+}
+
+func newWatcherOptions(opt []WatcherOption) *watcherOptions {
+	opts := &watcherOptions{
+		interval: 5 * time.Second,
+	}
+	for _, o := range opt {
+		o(opts)
+	}
+	return opts
+}
+
+// NewConfigWatcher - loads the config file with its includes and profile overlay and the NFGO_ env vars,
+// and starts watching the files.
+func NewConfigWatcher(confPath string, opt ...WatcherOption) (ConfigWatcher, error) {
+	opts := newWatcherOptions(opt)
+	var customConfig CustomConfig
+	if opts.newCustomConfig != nil {
+		customConfig = opts.newCustomConfig()
+	}
+	return newConfigWatcher([]Source{
+		NewFileSource(confPath, PollIntervalOption(opts.interval)),
+		NewEnvSource(SchemaOption(customConfig)),
+	}, opts)
+}
+
+// MustNewConfigWatcher -
+func MustNewConfigWatcher(confPath string, opt ...WatcherOption) ConfigWatcher {
+	w, err := NewConfigWatcher(confPath, opt...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return w
+}
+
+// NewSourcesConfigWatcher - loads the config from the sources, and starts watching the watchable ones.
+func NewSourcesConfigWatcher(sources []Source, opt ...WatcherOption) (ConfigWatcher, error) {
+	return newConfigWatcher(sources, newWatcherOptions(opt))
+}
+
+// MustNewSourcesConfigWatcher -
+func MustNewSourcesConfigWatcher(sources []Source, opt ...WatcherOption) ConfigWatcher {
+	w, err := NewSourcesConfigWatcher(sources, opt...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return w
+}
+
+func newConfigWatcher(sources []Source, opts *watcherOptions) (*configWatcher, error) {
+	w := &configWatcher{
+		sources: sources,
+		opts:    opts,
+		changed: make(chan struct{}, 1),
+	}
+	tree, err := loadSources(sources)
+	if err != nil {
+		return nil, err
+	}
+	data, err := yaml.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	config, err := w.newConfig(tree)
+	if err != nil {
+		return nil, err
+	}
+	w.config = config
+	w.digest = sha256.Sum256(data)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	for _, src := range sources {
+		if ws, ok := src.(WatchableSource); ok {
+			go ws.Watch(ctx, w.notify)
+		}
+	}
+	go w.watch(ctx)
+	return w, nil
+}
+
+type configWatcher struct {
+	sources     []Source
+	opts        *watcherOptions
+	mu          sync.RWMutex
+	config      *Config
+	digest      [sha256.Size]byte
+	subscribers []func(old, new *Config)
+	changed     chan struct{}
+	cancel      context.CancelFunc
+}
+
+func (w *configWatcher) Config() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.config
+}
+
+func (w *configWatcher) OnChange(fn func(old, new *Config)) {
+	w.mu.Lock()
+	w.subscribers = append(w.subscribers, fn)
+	w.mu.Unlock()
+}
+
+func (w *configWatcher) Close() error {
+	w.cancel()
+	return nil
+}
+
+func (w *configWatcher) newConfig(tree map[string]interface{}) (*Config, error) {
+	var customConfig CustomConfig
+	if w.opts.newCustomConfig != nil {
+		customConfig = w.opts.newCustomConfig()
+	}
+	return buildConfig(tree, customConfig)
+}
+
+// notify - coalesces the updates pushed while a reload is in progress.
+func (w *configWatcher) notify() {
+	select {
+	case w.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (w *configWatcher) watch(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.changed:
+			if err := w.reload(); err != nil {
+				log.Printf("nconf: fail to reload config: %s", err)
+			}
+		}
+	}
+}
+
+func (w *configWatcher) reload() error {
+	tree, err := loadSources(w.sources)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(data)
+	if digest == w.digest {
+		return nil
+	}
+	config, err := w.newConfig(tree)
+	if err != nil {
+		return err
+	}
+	w.digest = digest
+
+	w.mu.Lock()
+	old := w.config
+	w.config = config
+	subscribers := make([]func(old, new *Config), len(w.subscribers))
+	copy(subscribers, w.subscribers)
+	w.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(old, config)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nconf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigWatcher(t *testing.T) {
+	a := assert.New(t)
+	confPath := filepath.Join(t.TempDir(), "app.yaml")
+	a.Nil(os.WriteFile(confPath, []byte("log:\n  level: info\n"), 0644))
+
+	w, err := NewConfigWatcher(confPath, WatchIntervalOption(10*time.Millisecond))
+	a.Nil(err)
+	//nolint:errcheck
+	defer w.Close()
+	a.Equal("info", w.Config().Log.Level)
+
+	changed := make(chan [2]*Config, 1)
+	w.OnChange(func(old, new *Config) {
+		changed <- [2]*Config{old, new}
+	})
+
+	a.Nil(os.WriteFile(confPath, []byte("log:\n  level: debug\n"), 0644))
+	select {
+	case configs := <-changed:
+		a.Equal("info", configs[0].Log.Level)
+		a.Equal("debug", configs[1].Log.Level)
+		a.Equal(configs[1], w.Config())
+	case <-time.After(5 * time.Second):
+		a.Fail("the config is not reloaded")
+	}
+}
+
+func TestConfigWatcherInvalidConfig(t *testing.T) {
+	a := assert.New(t)
+	confPath := filepath.Join(t.TempDir(), "app.yaml")
+	a.Nil(os.WriteFile(confPath, []byte("log:\n  level: info\n"), 0644))
+
+	w, err := NewConfigWatcher(confPath, WatchIntervalOption(time.Hour))
+	a.Nil(err)
+	//nolint:errcheck
+	defer w.Close()
+
+	a.Nil(os.WriteFile(confPath, []byte("log: [\n"), 0644))
+	a.NotNil(w.(*configWatcher).reload())
+	a.Equal("info", w.Config().Log.Level)
+}
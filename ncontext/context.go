@@ -51,6 +51,74 @@ type MDC interface {
 	SetOther(key string, value interface{})
 }
 
+// ROTraceMDC - the span and the debug flag of the trace in the MDC created by NewMDC.
+// They are not on ROMDC so that the other implementations of ROMDC keep working, see ROTraceOf.
+type ROTraceMDC interface {
+	// SpanID - the span of the current service in the trace, see ntrace.Extract.
+	SpanID() string
+	// ParentSpanID - the span of the caller, empty if the trace is started by the current service.
+	ParentSpanID() string
+	// TraceState - the tracestate header of W3C Trace Context which is propagated as it is.
+	TraceState() string
+	// Sampled - whether the trace is sampled by the caller, it is the sampled flag of W3C Trace Context and B3.
+	Sampled() bool
+	// Debug - whether all the logs of the trace are kept at debug level, see nconst.HeaderDebug.
+	Debug() bool
+}
+
+// TraceMDC - the writable ROTraceMDC, see TraceOf.
+type TraceMDC interface {
+	ROTraceMDC
+	SetSpanID(spanID string)
+	SetParentSpanID(parentSpanID string)
+	SetTraceState(traceState string)
+	SetSampled(sampled bool)
+	SetDebug(debug bool)
+}
+
+// OthersRanger - the MDC whose others can be ranged over, see RangeOthers.
+type OthersRanger interface {
+	// RangeOthers - calls f for the others sequentially until f returns false.
+	RangeOthers(f func(key string, value interface{}) bool)
+}
+
+// ROTraceOf - the ROTraceMDC of romdc, it is empty if romdc does not implement ROTraceMDC.
+func ROTraceOf(romdc ROMDC) ROTraceMDC {
+	if t, ok := romdc.(ROTraceMDC); ok {
+		return t
+	}
+	return noTrace{}
+}
+
+// TraceOf - the TraceMDC of mdc, the values set to it are discarded if mdc does not implement TraceMDC.
+func TraceOf(mdc MDC) TraceMDC {
+	if t, ok := mdc.(TraceMDC); ok {
+		return t
+	}
+	return noTrace{}
+}
+
+// RangeOthers - calls f for the others of romdc sequentially until f returns false,
+// f is not called if romdc does not implement OthersRanger.
+func RangeOthers(romdc ROMDC, f func(key string, value interface{}) bool) {
+	if r, ok := romdc.(OthersRanger); ok {
+		r.RangeOthers(f)
+	}
+}
+
+type noTrace struct{}
+
+func (noTrace) SpanID() string                      { return "" }
+func (noTrace) ParentSpanID() string                { return "" }
+func (noTrace) TraceState() string                  { return "" }
+func (noTrace) Sampled() bool                       { return false }
+func (noTrace) Debug() bool                         { return false }
+func (noTrace) SetSpanID(spanID string)             {}
+func (noTrace) SetParentSpanID(parentSpanID string) {}
+func (noTrace) SetTraceState(traceState string)     {}
+func (noTrace) SetSampled(sampled bool)             {}
+func (noTrace) SetDebug(debug bool)                 {}
+
 // NewMDC -
 func NewMDC() MDC {
 	return &mdc{
@@ -83,24 +151,34 @@ func CurrentMDC(ctx context.Context) (ROMDC, error) {
 }
 
 type mdc struct {
-	clientType string
-	traceID    string
-	subjectID  string
-	rpcName    string
-	apiName    string
-	clinetIP   string
-	others     *sync.Map
+	clientType   string
+	traceID      string
+	spanID       string
+	parentSpanID string
+	traceState   string
+	sampled      bool
+	subjectID    string
+	rpcName      string
+	apiName      string
+	clinetIP     string
+	debug        bool
+	others       *sync.Map
 }
 
 func (m *mdc) Copy() MDC {
 	cm := &mdc{
-		clientType: m.clientType,
-		traceID:    m.traceID,
-		subjectID:  m.subjectID,
-		rpcName:    m.rpcName,
-		apiName:    m.apiName,
-		clinetIP:   m.clinetIP,
-		others:     &sync.Map{},
+		clientType:   m.clientType,
+		traceID:      m.traceID,
+		spanID:       m.spanID,
+		parentSpanID: m.parentSpanID,
+		traceState:   m.traceState,
+		sampled:      m.sampled,
+		subjectID:    m.subjectID,
+		rpcName:      m.rpcName,
+		apiName:      m.apiName,
+		clinetIP:     m.clinetIP,
+		debug:        m.debug,
+		others:       &sync.Map{},
 	}
 	m.others.Range(func(key, value interface{}) bool {
 		cm.others.Store(key, value)
@@ -125,6 +203,38 @@ func (m *mdc) SetTraceID(traceID string) {
 	m.traceID = traceID
 }
 
+func (m *mdc) SpanID() string {
+	return m.spanID
+}
+
+func (m *mdc) SetSpanID(spanID string) {
+	m.spanID = spanID
+}
+
+func (m *mdc) ParentSpanID() string {
+	return m.parentSpanID
+}
+
+func (m *mdc) SetParentSpanID(parentSpanID string) {
+	m.parentSpanID = parentSpanID
+}
+
+func (m *mdc) TraceState() string {
+	return m.traceState
+}
+
+func (m *mdc) SetTraceState(traceState string) {
+	m.traceState = traceState
+}
+
+func (m *mdc) Sampled() bool {
+	return m.sampled
+}
+
+func (m *mdc) SetSampled(sampled bool) {
+	m.sampled = sampled
+}
+
 func (m *mdc) SubjectID() string {
 	return m.subjectID
 }
@@ -156,6 +266,14 @@ func (m *mdc) SetClientIP(clinetIP string) {
 	m.clinetIP = clinetIP
 }
 
+func (m *mdc) Debug() bool {
+	return m.debug
+}
+
+func (m *mdc) SetDebug(debug bool) {
+	m.debug = debug
+}
+
 func (m *mdc) Other(key string) interface{} {
 	if v, ok := m.others.Load(key); ok {
 		return v
@@ -166,3 +284,9 @@ func (m *mdc) Other(key string) interface{} {
 func (m *mdc) SetOther(key string, value interface{}) {
 	m.others.Store(key, value)
 }
+
+func (m *mdc) RangeOthers(f func(key string, value interface{}) bool) {
+	m.others.Range(func(key, value interface{}) bool {
+		return f(key.(string), value)
+	})
+}
@@ -36,6 +36,12 @@ func TestMDCCopy(t *testing.T) {
 	m.SetRPCName("rpc")
 	m.SetSubjectID("s")
 	m.SetTraceID("t")
+	tm := TraceOf(m)
+	tm.SetSpanID("sp")
+	tm.SetParentSpanID("psp")
+	tm.SetTraceState("k=v")
+	tm.SetSampled(true)
+	tm.SetDebug(true)
 	m.SetOther("k1", "v1")
 	m.SetOther("k2", "v2")
 	cm := m.Copy()
@@ -47,9 +53,44 @@ func TestMDCCopy(t *testing.T) {
 	a.Equal("rpc", cm.RPCName())
 	a.Equal("s", cm.SubjectID())
 	a.Equal("t", cm.TraceID())
+	ctm := ROTraceOf(cm)
+	a.Equal("sp", ctm.SpanID())
+	a.Equal("psp", ctm.ParentSpanID())
+	a.Equal("k=v", ctm.TraceState())
+	a.True(ctm.Sampled())
+	a.True(ctm.Debug())
 	a.Equal("v1", cm.Other("k1"))
 	a.Equal("v2", cm.Other("k2"))
 	a.NotEqual(m, cm)
+
+	others := map[string]interface{}{}
+	RangeOthers(cm, func(key string, value interface{}) bool {
+		others[key] = value
+		return true
+	})
+	a.Equal(map[string]interface{}{"k1": "v1", "k2": "v2"}, others)
+}
+
+// legacyMDC - an implementation of MDC without the trace details and RangeOthers.
+type legacyMDC struct {
+	MDC
+}
+
+func TestTraceOfLegacyMDC(t *testing.T) {
+	a := assert.New(t)
+	m := legacyMDC{NewMDC()}
+	m.SetTraceID("t")
+	m.SetOther("k1", "v1")
+	TraceOf(m).SetSpanID("sp")
+	TraceOf(m).SetDebug(true)
+
+	a.Equal("t", m.TraceID())
+	a.Empty(ROTraceOf(m).SpanID())
+	a.False(ROTraceOf(m).Debug())
+	RangeOthers(m, func(key string, value interface{}) bool {
+		a.Fail("unexpected other", key)
+		return true
+	})
 }
 
 func TestBackground(t *testing.T) {
@@ -0,0 +1,76 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ncontext
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/nf-go/nfgo/nerrors"
+)
+
+// Budget - the time left before the deadline of ctx, ok is false if ctx has no deadline.
+func Budget(ctx context.Context) (budget time.Duration, ok bool) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return time.Until(deadline), true
+}
+
+// WithTimeout - context.WithTimeout, but timeout <= 0 means no timeout. The earlier deadline of ctx is kept.
+func WithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// CheckDeadline - returns nerrors.ErrDeadlineExceeded if the deadline of ctx is exceeded,
+// the callee should give up the work since the caller has given up as well.
+func CheckDeadline(ctx context.Context) error {
+	if budget, ok := Budget(ctx); ok && budget <= 0 {
+		return nerrors.ErrDeadlineExceeded
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nerrors.ErrDeadlineExceeded
+	}
+	return nil
+}
+
+// FormatBudget - formats the budget as the X-Timeout header, in milliseconds rounded up,
+// so it is 0 only if the deadline is exceeded.
+func FormatBudget(budget time.Duration) string {
+	if budget <= 0 {
+		return "0"
+	}
+	ms := (budget + time.Millisecond - 1) / time.Millisecond
+	return strconv.FormatInt(int64(ms), 10)
+}
+
+// ParseBudget - parses the X-Timeout header, ok is false if it is empty or invalid.
+func ParseBudget(value string) (budget time.Duration, ok bool) {
+	if value == "" {
+		return 0, false
+	}
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ms < 0 || ms > math.MaxInt64/int64(time.Millisecond) {
+		return 0, false
+	}
+	return time.Duration(ms) * time.Millisecond, true
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ncontext
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget(t *testing.T) {
+	a := assert.New(t)
+	_, ok := Budget(context.Background())
+	a.False(ok)
+	a.Nil(CheckDeadline(context.Background()))
+
+	ctx, cancel := WithTimeout(context.Background(), 0)
+	_, ok = Budget(ctx)
+	a.False(ok)
+	cancel()
+	a.Nil(CheckDeadline(ctx))
+
+	ctx, cancel = WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	budget, ok := Budget(ctx)
+	a.True(ok)
+	a.True(budget > 59*time.Second && budget <= time.Minute)
+	a.Nil(CheckDeadline(ctx))
+
+	short, cancel := WithTimeout(ctx, time.Second)
+	defer cancel()
+	budget, _ = Budget(short)
+	a.True(budget <= time.Second)
+	long, cancel := WithTimeout(short, time.Hour)
+	defer cancel()
+	budget, _ = Budget(long)
+	a.True(budget <= time.Second)
+
+	exceeded, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	a.Equal(nerrors.ErrDeadlineExceeded, CheckDeadline(exceeded))
+}
+
+func TestFormatBudget(t *testing.T) {
+	a := assert.New(t)
+	a.Equal("0", FormatBudget(-time.Second))
+	a.Equal("0", FormatBudget(0))
+	a.Equal("1", FormatBudget(time.Microsecond))
+	a.Equal("1500", FormatBudget(1500*time.Millisecond))
+
+	budget, ok := ParseBudget("1500")
+	a.True(ok)
+	a.Equal(1500*time.Millisecond, budget)
+	budget, ok = ParseBudget("0")
+	a.True(ok)
+	a.Equal(time.Duration(0), budget)
+	for _, value := range []string{"", "-1", "1s", "99999999999999999999"} {
+		_, ok = ParseBudget(value)
+		a.False(ok, value)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ncontext
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// PanicHandler - handles the panic r recovered by Recover, stack is the one of the panicking goroutine.
+type PanicHandler func(ctx context.Context, r interface{}, stack []byte)
+
+var panicHandler atomic.Pointer[PanicHandler]
+
+func init() {
+	SetPanicHandler(func(ctx context.Context, r interface{}, stack []byte) {
+		log.Printf("panic recovered: %v\n%s", r, stack)
+	})
+}
+
+// SetPanicHandler - sets the handler of the panics recovered by Recover,
+// nlog sets the one which logs them by nlog.Logger.
+func SetPanicHandler(handler PanicHandler) {
+	panicHandler.Store(&handler)
+}
+
+// Recover - recovers the panic of the goroutine and handles it by the PanicHandler,
+// it must be deferred directly, e.g. defer ncontext.Recover(ctx).
+func Recover(ctx context.Context) {
+	if r := recover(); r != nil {
+		(*panicHandler.Load())(ctx, r, debug.Stack())
+	}
+}
+
+// Go - calls fn in a new goroutine with a context which is never canceled and has a copy of the MDC of ctx,
+// see Background. The panic of fn is recovered, see Recover.
+func Go(ctx context.Context, fn func(ctx context.Context)) {
+	ctx = Background(ctx)
+	go func() {
+		defer Recover(ctx)
+		fn(ctx)
+	}()
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ncontext
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGo(t *testing.T) {
+	a := assert.New(t)
+	recovered := make(chan interface{}, 1)
+	old := *panicHandler.Load()
+	SetPanicHandler(func(ctx context.Context, r interface{}, stack []byte) {
+		mdc, _ := CurrentMDC(ctx)
+		a.Equal("t1", mdc.TraceID())
+		a.NotEmpty(stack)
+		recovered <- r
+	})
+	defer SetPanicHandler(old)
+
+	mdc := NewMDC()
+	mdc.SetTraceID("t1")
+	ctx, cancel := context.WithCancel(WithMDC(context.Background(), mdc))
+	cancel()
+
+	traceIDs := make(chan string, 1)
+	Go(ctx, func(ctx context.Context) {
+		a.Nil(ctx.Err())
+		m, _ := CurrentMDC(ctx)
+		a.NotSame(mdc, m)
+		traceIDs <- m.TraceID()
+		panic("crash")
+	})
+	a.Equal("t1", <-traceIDs)
+	a.Equal("crash", <-recovered)
+}
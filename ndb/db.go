@@ -71,6 +71,9 @@ func NewDB(dbConfig *nconf.DbConfig, opt ...DBOption) (*gorm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("fail to open db: %w", err)
 	}
+	if err := registerTracingCallbacks(db); err != nil {
+		return nil, fmt.Errorf("fail to register the tracing callbacks: %w", err)
+	}
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, err
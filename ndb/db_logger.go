@@ -26,30 +26,41 @@ import (
 	"gorm.io/gorm/utils"
 )
 
+// LoggerName - the name of the logger of gorm, the sql is logged if its level is debug, see nlog.SetNamedLevel.
+const LoggerName = "ndb"
+
 // https://github.com/go-gorm/gorm/blob/master/logger/logger.go
 type dbLogger struct {
+	// LogLevel - it follows the level of the ndb logger if it is not set by LogMode.
 	LogLevel      logger.LogLevel
 	SlowThreshold time.Duration
+	// level - the level of the ndb logger, it is read per SQL statement without locking.
+	level nlog.AtomicLevel
 }
 
 func newLogger(config *nconf.DbConfig) *dbLogger {
-	nlogLevel := nlog.Logger(context.Background()).LevelString()
+	return &dbLogger{
+		SlowThreshold: config.SlowQueryThreshold,
+		level:         nlog.NamedLevel(LoggerName),
+	}
+}
+
+func (l *dbLogger) logLevel() logger.LogLevel {
+	if l.LogLevel != 0 {
+		return l.LogLevel
+	}
 	logLevel := logger.Silent
-	switch nlogLevel {
-	case "debug":
+	switch l.level.Level() {
+	case nlog.DebugLevel:
 		logLevel = logger.Info
-	case "info":
+	case nlog.InfoLevel:
 		logLevel = logger.Warn
-	case "warn":
+	case nlog.WarnLevel:
 		logLevel = logger.Warn
-	case "error":
+	case nlog.ErrorLevel:
 		logLevel = logger.Error
 	}
-
-	return &dbLogger{
-		SlowThreshold: config.SlowQueryThreshold,
-		LogLevel:      logLevel,
-	}
+	return logLevel
 }
 
 func (l *dbLogger) LogMode(level logger.LogLevel) logger.Interface {
@@ -59,35 +70,35 @@ func (l *dbLogger) LogMode(level logger.LogLevel) logger.Interface {
 }
 
 func (l *dbLogger) Info(ctx context.Context, msg string, data ...interface{}) {
-	if l.LogLevel >= logger.Info {
-		nlog.Logger(ctx).Infof(msg, data)
+	if l.logLevel() >= logger.Info {
+		nlog.NamedLogger(ctx, LoggerName).Infof(msg, data)
 	}
 
 }
 
 func (l *dbLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
-	if l.LogLevel >= logger.Warn {
-		nlog.Logger(ctx).Warnf(msg, data)
+	if l.logLevel() >= logger.Warn {
+		nlog.NamedLogger(ctx, LoggerName).Warnf(msg, data)
 	}
 }
 
 func (l *dbLogger) Error(ctx context.Context, msg string, data ...interface{}) {
-	if l.LogLevel >= logger.Error {
-		nlog.Logger(ctx).Errorf(msg, data)
+	if l.logLevel() >= logger.Error {
+		nlog.NamedLogger(ctx, LoggerName).Errorf(msg, data)
 	}
 }
 
 func (l *dbLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
-	if l.LogLevel > logger.Silent {
+	if logLevel := l.logLevel(); logLevel > logger.Silent {
 		elapsed := time.Since(begin)
 		switch {
-		case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.LogLevel >= logger.Error:
+		case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && logLevel >= logger.Error:
 			fileWithLineNum := utils.FileWithLineNum()
 			logEnry(ctx, fileWithLineNum, elapsed, fc).WithError(err).Error()
-		case elapsed > l.SlowThreshold && l.SlowThreshold != 0 && l.LogLevel >= logger.Warn:
+		case elapsed > l.SlowThreshold && l.SlowThreshold != 0 && logLevel >= logger.Warn:
 			fileWithLineNum := utils.FileWithLineNum()
 			logEnry(ctx, fileWithLineNum, elapsed, fc).Warn()
-		case l.LogLevel >= logger.Info:
+		case logLevel >= logger.Info:
 			fileWithLineNum := utils.FileWithLineNum()
 			logEnry(ctx, fileWithLineNum, elapsed, fc).Debug()
 		}
@@ -96,7 +107,7 @@ func (l *dbLogger) Trace(ctx context.Context, begin time.Time, fc func() (string
 
 func logEnry(ctx context.Context, fileWithLineNum string, elapsed time.Duration, fc func() (string, int64)) nlog.NLogger {
 	sql, rows := fc()
-	return nlog.Logger(ctx).WithFields(nlog.Fields{
+	return nlog.NamedLogger(ctx, LoggerName).WithFields(nlog.Fields{
 		"fileWithLineNum": fileWithLineNum,
 		"rowsAffected":    rows,
 		"elapsed":         elapsed.Milliseconds(),
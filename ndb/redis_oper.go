@@ -16,16 +16,21 @@ package ndb
 
 import (
 	"bytes"
+	"context"
 	"encoding/gob"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
+	"github.com/nf-go/nfgo/ntrace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // NewRedisOper -
 func NewRedisOper(redisPool RedisPool) RedisOper {
 	return &redisOperImpl{
 		redisPool: redisPool,
+		ctx:       context.Background(),
 	}
 }
 
@@ -50,16 +55,49 @@ type RedisOper interface {
 	DeleteByKeyValue(key string, val string) error
 }
 
+// RedisContextOper - a RedisOper that can be bound to a context.
+type RedisContextOper interface {
+	RedisOper
+	// WithContext - returns the RedisOper whose commands are traced as the children of the span in ctx,
+	// see ntrace.StartSpan.
+	WithContext(ctx context.Context) RedisOper
+}
+
+// RedisOperWithContext - binds ctx to the redisOper if it is a RedisContextOper,
+// otherwise returns the redisOper as it is.
+func RedisOperWithContext(ctx context.Context, redisOper RedisOper) RedisOper {
+	if ctxOper, ok := redisOper.(RedisContextOper); ok {
+		return ctxOper.WithContext(ctx)
+	}
+	return redisOper
+}
+
 type redisOperImpl struct {
 	redisPool RedisPool
+	ctx       context.Context
 }
 
 func (r *redisOperImpl) Conn() redis.Conn {
-	return r.redisPool.Get()
+	return r.conn()
 }
 
-func (r *redisOperImpl) GetString(key string) (string, error) {
+func (r *redisOperImpl) WithContext(ctx context.Context) RedisOper {
+	return &redisOperImpl{
+		redisPool: r.redisPool,
+		ctx:       ctx,
+	}
+}
+
+func (r *redisOperImpl) conn() redis.Conn {
 	conn := r.redisPool.Get()
+	if ntrace.Enabled() {
+		return &tracingConn{Conn: conn, ctx: r.ctx}
+	}
+	return conn
+}
+
+func (r *redisOperImpl) GetString(key string) (string, error) {
+	conn := r.conn()
 	//nolint:errcheck
 	defer conn.Close()
 	val, err := redis.String(conn.Do("GET", key))
@@ -70,7 +108,7 @@ func (r *redisOperImpl) GetString(key string) (string, error) {
 }
 
 func (r *redisOperImpl) SetString(key, value string) error {
-	conn := r.redisPool.Get()
+	conn := r.conn()
 	//nolint:errcheck
 	defer conn.Close()
 	_, err := conn.Do("SET", key, value)
@@ -78,7 +116,7 @@ func (r *redisOperImpl) SetString(key, value string) error {
 }
 
 func (r *redisOperImpl) SetStringOpts(key, value string, setnx bool, setxx bool, ttl time.Duration) error {
-	conn := r.redisPool.Get()
+	conn := r.conn()
 	//nolint:errcheck
 	defer conn.Close()
 	args := []interface{}{key, value}
@@ -96,7 +134,7 @@ func (r *redisOperImpl) SetStringOpts(key, value string, setnx bool, setxx bool,
 }
 
 func (r *redisOperImpl) GetObject(key string, model interface{}) (interface{}, error) {
-	conn := r.redisPool.Get()
+	conn := r.conn()
 	//nolint:errcheck
 	defer conn.Close()
 	data, err := redis.Bytes(conn.Do("GET", key))
@@ -111,7 +149,7 @@ func (r *redisOperImpl) GetObject(key string, model interface{}) (interface{}, e
 }
 
 func (r *redisOperImpl) SetObject(key string, value interface{}) error {
-	conn := r.redisPool.Get()
+	conn := r.conn()
 	//nolint:errcheck
 	defer conn.Close()
 	var buf bytes.Buffer
@@ -123,7 +161,7 @@ func (r *redisOperImpl) SetObject(key string, value interface{}) error {
 }
 
 func (r *redisOperImpl) SetObjectOpts(key string, value interface{}, setnx bool, setxx bool, ttl time.Duration) error {
-	conn := r.redisPool.Get()
+	conn := r.conn()
 	//nolint:errcheck
 	defer conn.Close()
 	var buf bytes.Buffer
@@ -145,7 +183,7 @@ func (r *redisOperImpl) SetObjectOpts(key string, value interface{}, setnx bool,
 }
 
 func (r *redisOperImpl) del(delCmd string, key string) error {
-	conn := r.redisPool.Get()
+	conn := r.conn()
 	//nolint:errcheck
 	defer conn.Close()
 	_, err := conn.Do(delCmd, key)
@@ -153,7 +191,7 @@ func (r *redisOperImpl) del(delCmd string, key string) error {
 }
 
 func (r *redisOperImpl) dels(delCmd string, keys ...string) error {
-	conn := r.redisPool.Get()
+	conn := r.conn()
 	//nolint:errcheck
 	defer conn.Close()
 	if _, err := conn.Do("MULTI"); err != nil {
@@ -186,7 +224,7 @@ func (r *redisOperImpl) Deletes(keys ...string) error {
 }
 
 func (r *redisOperImpl) DeleteByKeyValue(key string, val string) error {
-	conn := r.redisPool.Get()
+	conn := r.conn()
 	//nolint:errcheck
 	defer conn.Close()
 	v, err := redis.String(conn.Do("GET", key))
@@ -204,3 +242,23 @@ func (r *redisOperImpl) DeleteByKeyValue(key string, val string) error {
 
 	return nil
 }
+
+// tracingConn - traces the commands sent by Do.
+type tracingConn struct {
+	redis.Conn
+	ctx context.Context
+}
+
+func (c *tracingConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	// the empty command flushes the pending commands
+	if cmd == "" {
+		return c.Conn.Do(cmd, args...)
+	}
+	_, span := ntrace.StartSpan(c.ctx, "redis."+cmd,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNameRedis, semconv.DBOperationName(cmd)),
+	)
+	reply, err := c.Conn.Do(cmd, args...)
+	ntrace.EndSpan(span, err)
+	return reply, err
+}
@@ -29,6 +29,8 @@ type ErrGroup struct {
 	cancel func()
 
 	wg sync.WaitGroup
+	// sem - the tokens of the active goroutines, nil means no limit
+	sem chan struct{}
 
 	errOnce sync.Once
 	err     error
@@ -39,22 +41,57 @@ type ErrGroup struct {
 // The derived Context is canceled the first time a function passed to Go
 // returns a non-nil error or the first time Wait returns, whichever occurs
 // first.
-func NewErrGroup(ctx context.Context) (*ErrGroup, context.Context) {
+func NewErrGroup(ctx context.Context, opt ...ErrGroupOption) (*ErrGroup, context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
-	return &ErrGroup{
+	g := &ErrGroup{
 		cancel: cancel,
-	}, ctx
+	}
+	for _, o := range opt {
+		o(g)
+	}
+	return g, ctx
+}
+
+// ErrGroupOption -
+type ErrGroupOption func(*ErrGroup)
+
+// MaxConcurrencyOption - limits the number of the active goroutines of the group to n, n <= 0 means no limit.
+func MaxConcurrencyOption(n int) ErrGroupOption {
+	return func(g *ErrGroup) {
+		if n > 0 {
+			g.sem = make(chan struct{}, n)
+		}
+	}
 }
 
-// Go - Go calls the given function in a new goroutine.
+// Go - Go calls the given function in a new goroutine,
+// it blocks until the number of the active goroutines is below the limit, see MaxConcurrencyOption.
 //
 // The first call to return a non-nil error cancels the group; its error will be
 // returned by Wait.
 func (g *ErrGroup) Go(f func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
 	g.wg.Add(1)
 	go g.doGo(f)
 }
 
+// TryGo - calls the given function in a new goroutine only if the number of the active goroutines
+// is below the limit, it reports whether the function is called.
+func (g *ErrGroup) TryGo(f func() error) bool {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	g.wg.Add(1)
+	go g.doGo(f)
+	return true
+}
+
 func (g *ErrGroup) doGo(f func() error) {
 	var err error
 	defer func() {
@@ -71,6 +108,9 @@ func (g *ErrGroup) doGo(f func() error) {
 				}
 			})
 		}
+		if g.sem != nil {
+			<-g.sem
+		}
 		g.wg.Done()
 	}()
 	err = f()
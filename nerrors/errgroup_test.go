@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -190,3 +191,49 @@ func TestErrGroupGoPanic(t *testing.T) {
 	err := g.Wait()
 	assert.Contains(t, err.Error(), "nerrors errgroup panic recoverd: crash")
 }
+
+func TestErrGroupMaxConcurrency(t *testing.T) {
+	a := assert.New(t)
+	g, _ := NewErrGroup(context.Background(), MaxConcurrencyOption(2))
+	release := make(chan struct{})
+	started := make(chan struct{}, 5)
+	var active, maxActive int32
+	var mu sync.Mutex
+	task := func() error {
+		mu.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		mu.Unlock()
+		started <- struct{}{}
+		<-release
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return nil
+	}
+	a.True(g.TryGo(task))
+	a.True(g.TryGo(task))
+	a.False(g.TryGo(task))
+	<-started
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 3; i++ {
+			g.Go(task)
+		}
+		close(done)
+	}()
+	close(release)
+	<-done
+	a.Nil(g.Wait())
+	a.Equal(int32(2), maxActive)
+
+	var unlimited ErrGroup
+	for i := 0; i < 10; i++ {
+		a.True(unlimited.TryGo(func() error { return nil }))
+	}
+	a.Nil(unlimited.Wait())
+}
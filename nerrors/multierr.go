@@ -29,3 +29,9 @@ func Combine(errors ...error) error {
 func Append(left error, right error) error {
 	return multierr.Append(left, right)
 }
+
+// Errors - returns the errors combined by Combine or Append,
+// a single error is returned as a slice of one item and a nil error as an empty slice.
+func Errors(err error) []error {
+	return multierr.Errors(err)
+}
@@ -23,6 +23,8 @@ var (
 	ErrUnauthorized = NewBizError(-2, "unauthorized")
 	// ErrForbidden -
 	ErrForbidden = NewBizError(-3, "forbidden")
+	// ErrDeadlineExceeded - the deadline of the request is exceeded, so the work is dropped since the caller gave up.
+	ErrDeadlineExceeded = NewBizError(-4, "deadline exceeded")
 )
 
 // BizError -
@@ -15,6 +15,10 @@
 package nerrors
 
 import (
+	stderrors "errors"
+	"fmt"
+	"runtime"
+
 	"github.com/pkg/errors"
 )
 
@@ -55,3 +59,56 @@ func Wrap(err error, message string) error {
 func Wrapf(err error, format string, args ...interface{}) error {
 	return errors.Wrapf(err, format, args...)
 }
+
+// wrapFuncs - the funcs above which record the stacks, they are not the origins of the errors.
+var wrapFuncs = map[string]struct{}{
+	"github.com/nf-go/nfgo/nerrors.New":       {},
+	"github.com/nf-go/nfgo/nerrors.Errorf":    {},
+	"github.com/nf-go/nfgo/nerrors.WithStack": {},
+	"github.com/nf-go/nfgo/nerrors.Wrap":      {},
+	"github.com/nf-go/nfgo/nerrors.Wrapf":     {},
+}
+
+// Chain - the messages of err and the errors it wraps, from the outermost to the innermost.
+// The same message of the adjacent errors, such as the one annotated by WithStack, appears once.
+func Chain(err error) []string {
+	var chain []string
+	for ; err != nil; err = stderrors.Unwrap(err) {
+		if msg := err.Error(); len(chain) == 0 || chain[len(chain)-1] != msg {
+			chain = append(chain, msg)
+		}
+	}
+	return chain
+}
+
+// StackTrace - the innermost stack trace recorded by New, Errorf, WithStack, Wrap or Wrapf in err and the errors it wraps,
+// one "function file:line" per frame, nil if there is none.
+func StackTrace(err error) []string {
+	type stackTracer interface {
+		StackTrace() errors.StackTrace
+	}
+	var stack errors.StackTrace
+	for ; err != nil; err = stderrors.Unwrap(err) {
+		if tracer, ok := err.(stackTracer); ok {
+			stack = tracer.StackTrace()
+		}
+	}
+	frames := make([]string, 0, len(stack))
+	for _, f := range stack {
+		// a Frame is the program counter + 1
+		pc := uintptr(f) - 1
+		fn := runtime.FuncForPC(pc)
+		if fn == nil {
+			continue
+		}
+		if _, ok := wrapFuncs[fn.Name()]; ok && len(frames) == 0 {
+			continue
+		}
+		file, line := fn.FileLine(pc)
+		frames = append(frames, fmt.Sprintf("%s %s:%d", fn.Name(), file, line))
+	}
+	if len(frames) == 0 {
+		return nil
+	}
+	return frames
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nerrors
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func queryUser() error {
+	return New("db is down")
+}
+
+func TestChainAndStackTrace(t *testing.T) {
+	a := assert.New(t)
+	err := Wrap(Wrapf(queryUser(), "query user %d", 1), "login")
+	a.Equal([]string{"login: query user 1: db is down", "query user 1: db is down", "db is down"}, Chain(err))
+
+	stack := StackTrace(err)
+	a.True(strings.HasPrefix(stack[0], "github.com/nf-go/nfgo/nerrors.queryUser "))
+	a.Contains(stack[0], "nerrors/wrap_test.go:")
+	a.True(strings.HasPrefix(stack[1], "github.com/nf-go/nfgo/nerrors.TestChainAndStackTrace "))
+
+	plain := errors.New("plain")
+	a.Equal([]string{"plain"}, Chain(WithStack(plain)))
+	a.Nil(StackTrace(plain))
+	a.Nil(Chain(nil))
+}
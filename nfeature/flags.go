@@ -0,0 +1,94 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nfeature
+
+import (
+	"context"
+	"hash/fnv"
+	"sync/atomic"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nutil/ntypes"
+)
+
+// Flags - evaluates the feature flags of the config.
+type Flags interface {
+	// Enabled - whether the flag is on for the client type and the subject id of the MDC of ctx,
+	// an undefined flag is off.
+	Enabled(ctx context.Context, name string) bool
+	// EnabledFor - whether the flag is on for the client type and the subject id.
+	EnabledFor(name string, clientType string, subjectID string) bool
+	// OnConfigChange - replaces the flags with the ones of the new config, see nconf.ConfigWatcher.
+	OnConfigChange(old, new *nconf.Config)
+}
+
+// NewFlags -
+func NewFlags(config *nconf.Config) Flags {
+	f := &flags{}
+	f.features.Store(&config.Features)
+	return f
+}
+
+// NewWatchedFlags - the flags which are replaced whenever the watcher reloads the config.
+func NewWatchedFlags(watcher nconf.ConfigWatcher) Flags {
+	f := NewFlags(watcher.Config())
+	watcher.OnChange(f.OnConfigChange)
+	return f
+}
+
+type flags struct {
+	features atomic.Pointer[nconf.FeaturesConfig]
+}
+
+func (f *flags) Enabled(ctx context.Context, name string) bool {
+	var clientType, subjectID string
+	if mdc, err := ncontext.CurrentMDC(ctx); err == nil {
+		clientType, subjectID = mdc.ClientType(), mdc.SubjectID()
+	}
+	return f.EnabledFor(name, clientType, subjectID)
+}
+
+func (f *flags) EnabledFor(name string, clientType string, subjectID string) bool {
+	feature := (*f.features.Load())[name]
+	if feature == nil || (feature.Enabled != nil && !*feature.Enabled) {
+		return false
+	}
+	for _, t := range feature.ClientTypes {
+		if t == clientType {
+			return true
+		}
+	}
+	if feature.Percentage != nil {
+		return subjectID != "" && bucket(name, subjectID) < *feature.Percentage
+	}
+	return len(feature.ClientTypes) == 0
+}
+
+func (f *flags) OnConfigChange(old, new *nconf.Config) {
+	if ntypes.IsNotNil(new) {
+		f.features.Store(&new.Features)
+	}
+}
+
+// bucket - maps the subject to [0, 100) stably, the flag name is hashed together
+// so that the rollouts of different flags do not hit the same subjects.
+func bucket(name string, subjectID string) float64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(subjectID))
+	return float64(h.Sum32()%10000) / 100
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nfeature
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContext(clientType string, subjectID string) context.Context {
+	mdc := ncontext.NewMDC()
+	mdc.SetClientType(clientType)
+	mdc.SetSubjectID(subjectID)
+	return ncontext.WithMDC(context.Background(), mdc)
+}
+
+func TestFlags(t *testing.T) {
+	a := assert.New(t)
+	config := nconf.MustNewConfig([]byte(`
+features:
+  newCheckout: true
+  oldCheckout: false
+  betaSearch:
+    clientTypes: [ios]
+  darkMode:
+    percentage: 30
+    clientTypes: [internal]
+  killed:
+    enabled: false
+    percentage: 100
+`))
+	flags := NewFlags(config)
+
+	ctx := newContext("android", "u1")
+	a.True(flags.Enabled(ctx, "newCheckout"))
+	a.False(flags.Enabled(ctx, "oldCheckout"))
+	a.False(flags.Enabled(ctx, "notExist"))
+	a.False(flags.Enabled(ctx, "killed"))
+	a.False(flags.Enabled(ctx, "betaSearch"))
+	a.True(flags.Enabled(newContext("ios", ""), "betaSearch"))
+	a.True(flags.Enabled(context.Background(), "newCheckout"))
+
+	a.True(flags.EnabledFor("darkMode", "internal", ""))
+	a.False(flags.EnabledFor("darkMode", "android", ""))
+	enabled := 0
+	for i := 0; i < 1000; i++ {
+		subjectID := fmt.Sprint(i)
+		if flags.EnabledFor("darkMode", "android", subjectID) {
+			enabled++
+			// stable for the same subject
+			a.True(flags.EnabledFor("darkMode", "web", subjectID))
+		}
+	}
+	a.InDelta(300, enabled, 60)
+
+	flags.OnConfigChange(config, nconf.MustNewConfig([]byte("features:\n  oldCheckout: true\n")))
+	a.True(flags.Enabled(ctx, "oldCheckout"))
+	a.False(flags.Enabled(ctx, "newCheckout"))
+}
@@ -16,13 +16,16 @@ package njob
 
 import (
 	"context"
+	"time"
 
 	"github.com/nf-go/nfgo/nconf"
 	"github.com/nf-go/nfgo/ncontext"
 	"github.com/nf-go/nfgo/nerrors"
 	"github.com/nf-go/nfgo/nlog"
-	"github.com/nf-go/nfgo/nutil/ncrypto"
+	"github.com/nf-go/nfgo/ntrace"
 	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Job -
@@ -46,18 +49,28 @@ type Jobs map[string]Job
 
 func newJobContext(jobName string) context.Context {
 	mdc := ncontext.NewMDC()
-	traceID, _ := ncrypto.UUID()
+	traceID, _ := ntrace.NewTraceID()
+	spanID, _ := ntrace.NewSpanID()
 	mdc.SetTraceID(traceID)
+	tmdc := ncontext.TraceOf(mdc)
+	tmdc.SetSpanID(spanID)
+	tmdc.SetSampled(true)
 	mdc.SetSubjectID(jobName)
 
 	ctx := context.Background()
 	return ncontext.WithMDC(ctx, mdc)
 }
 
-func newRobfigCronJob(conf *nconf.CronJobConfig, job Job) cron.Job {
+// newRobfigCronJob - the context of every run is canceled after the timeout, see cron.timeout.
+func newRobfigCronJob(conf *nconf.CronJobConfig, timeout time.Duration, job Job) cron.Job {
 	fn := func() {
-		ctx := newJobContext(conf.Name)
-		if err := job.Run(ctx); err != nil {
+		ctx, cancel := ncontext.WithTimeout(newJobContext(conf.Name), timeout)
+		defer cancel()
+		ctx, span := ntrace.StartEntrySpan(ctx, "job "+conf.Name,
+			trace.WithAttributes(attribute.String("nfgo.job.name", conf.Name)))
+		err := job.Run(ctx)
+		ntrace.EndSpan(span, err)
+		if err != nil {
 			logger := nlog.Logger(ctx).WithError(err)
 			if bizErr, ok := err.(nerrors.BizError); ok {
 				logger.Infof("job %s completed but an biz error occurred %s", conf.Name, bizErr)
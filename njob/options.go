@@ -14,10 +14,13 @@
 
 package njob
 
+import "github.com/nf-go/nfgo/nconf"
+
 type serverOptions struct {
 	funcJobs         FuncJobs
 	jobs             Jobs
 	distributedMutex DistributedMutex
+	configWatcher    nconf.ConfigWatcher
 }
 
 // ServerOption -
@@ -43,3 +46,10 @@ func DistributedMutexOption(distributedMutex DistributedMutex) ServerOption {
 		opts.distributedMutex = distributedMutex
 	}
 }
+
+// ConfigWatcherOption - reschedules the cron jobs when the cron config is reloaded by the watcher.
+func ConfigWatcherOption(configWatcher nconf.ConfigWatcher) ServerOption {
+	return func(opts *serverOptions) {
+		opts.configWatcher = configWatcher
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/nf-go/nfgo/nconf"
 	"github.com/nf-go/nfgo/nlog"
@@ -33,10 +35,20 @@ type Server interface {
 
 // JobServer -
 type jobServer struct {
-	config *nconf.Config
-	opts   *serverOptions
-	c      *cron.Cron
-	stop   chan struct{}
+	config  *nconf.Config
+	opts    *serverOptions
+	c       *cron.Cron
+	stop    chan struct{}
+	mu      sync.Mutex
+	started bool
+	entries map[string]*jobEntry
+}
+
+type jobEntry struct {
+	id       cron.EntryID
+	schedule string
+	// timeout - the effective timeout of the job, see nconf.CronConfig.JobTimeout
+	timeout time.Duration
 }
 
 // NewServer -
@@ -63,12 +75,17 @@ func NewServer(config *nconf.Config, opt ...ServerOption) (Server, error) {
 	}
 
 	c := cron.New(cron.WithChain(jobWrappers...))
-	return &jobServer{
-		opts:   opts,
-		config: config,
-		c:      c,
-		stop:   make(chan struct{}),
-	}, nil
+	s := &jobServer{
+		opts:    opts,
+		config:  config,
+		c:       c,
+		stop:    make(chan struct{}),
+		entries: map[string]*jobEntry{},
+	}
+	if opts.configWatcher != nil {
+		opts.configWatcher.OnChange(s.onConfigChange)
+	}
+	return s, nil
 }
 
 // MustNewServer -
@@ -82,7 +99,11 @@ func MustNewServer(config *nconf.Config, opt ...ServerOption) Server {
 
 // Serve -
 func (s *jobServer) Serve() error {
-	if err := s.addJobs(); err != nil {
+	s.mu.Lock()
+	err := s.addJobs()
+	s.started = err == nil
+	s.mu.Unlock()
+	if err != nil {
 		return err
 	}
 	s.c.Start()
@@ -93,31 +114,81 @@ func (s *jobServer) Serve() error {
 func (s *jobServer) addJobs() error {
 	cronConf := s.config.CronConfig
 	for _, conf := range cronConf.CronJobs {
-		if fn, ok := s.opts.funcJobs[conf.Name]; ok {
-			if err := s.addJob(conf, fn); err != nil {
-				return fmt.Errorf("fail to init croJob %s: %w", conf.Name, err)
-			}
-		} else if job, ok := s.opts.jobs[conf.Name]; ok {
-			if err := s.addJob(conf, job); err != nil {
-				return fmt.Errorf("fail to init croJob %s: %w", conf.Name, err)
-			}
-		} else {
-			nlog.Warnf("Please provide a job or a jobfunc for %s, use njob.JobsOption or njob.JobFuncsOption", conf.Name)
+		if err := s.addJobByConf(conf); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
+func (s *jobServer) addJobByConf(conf *nconf.CronJobConfig) error {
+	if fn, ok := s.opts.funcJobs[conf.Name]; ok {
+		if err := s.addJob(conf, fn); err != nil {
+			return fmt.Errorf("fail to init croJob %s: %w", conf.Name, err)
+		}
+	} else if job, ok := s.opts.jobs[conf.Name]; ok {
+		if err := s.addJob(conf, job); err != nil {
+			return fmt.Errorf("fail to init croJob %s: %w", conf.Name, err)
+		}
+	} else {
+		nlog.Warnf("Please provide a job or a jobfunc for %s, use njob.JobsOption or njob.JobFuncsOption", conf.Name)
 	}
 	return nil
 }
 
 func (s *jobServer) addJob(conf *nconf.CronJobConfig, j Job) error {
-	job := newRobfigCronJob(conf, j)
+	timeout := s.config.CronConfig.JobTimeout(conf)
+	job := newRobfigCronJob(conf, timeout, j)
 	if s.opts.distributedMutex != nil {
 		job = cron.NewChain(
 			distributedRunning(s.config, conf.Name, s.opts.distributedMutex),
 		).Then(job)
 	}
-	_, err := s.c.AddJob(conf.Schedule, job)
-	return err
+	id, err := s.c.AddJob(conf.Schedule, job)
+	if err != nil {
+		return err
+	}
+	s.entries[conf.Name] = &jobEntry{id: id, schedule: conf.Schedule, timeout: timeout}
+	return nil
+}
+
+// onConfigChange - reschedules the jobs whose schedule or timeout is changed, removes the jobs which are no longer configured.
+func (s *jobServer) onConfigChange(old, new *nconf.Config) {
+	if new.CronConfig == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config = new
+	if !s.started {
+		return
+	}
+
+	confs := map[string]*nconf.CronJobConfig{}
+	for _, conf := range new.CronConfig.CronJobs {
+		confs[conf.Name] = conf
+	}
+	for name, entry := range s.entries {
+		if conf, ok := confs[name]; !ok || conf.Schedule != entry.schedule ||
+			new.CronConfig.JobTimeout(conf) != entry.timeout {
+			s.c.Remove(entry.id)
+			delete(s.entries, name)
+			nlog.Infof("cron job %s is removed from schedule %s", name, entry.schedule)
+		}
+	}
+	for _, conf := range new.CronConfig.CronJobs {
+		if _, ok := s.entries[conf.Name]; ok {
+			continue
+		}
+		if err := s.addJobByConf(conf); err != nil {
+			nlog.Errorf("fail to reschedule cron job %s: %s", conf.Name, err)
+			continue
+		}
+		if _, ok := s.entries[conf.Name]; ok {
+			nlog.Infof("cron job %s is scheduled with %s", conf.Name, conf.Schedule)
+		}
+	}
 }
 
 // MustServe -
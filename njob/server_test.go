@@ -0,0 +1,71 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package njob
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/stretchr/testify/assert"
+)
+
+func cronConfig(timeout, jobTimeout time.Duration) *nconf.Config {
+	return &nconf.Config{CronConfig: &nconf.CronConfig{
+		Timeout:  timeout,
+		CronJobs: []*nconf.CronJobConfig{{Name: "foo", Schedule: "@every 1h", Timeout: jobTimeout}},
+	}}
+}
+
+func TestJobTimeoutOnConfigChange(t *testing.T) {
+	a := assert.New(t)
+	var budget time.Duration
+	server, err := NewServer(cronConfig(time.Minute, 0), FuncJobsOption(FuncJobs{
+		"foo": func(ctx context.Context) error {
+			budget, _ = ncontext.Budget(ctx)
+			return nil
+		},
+	}))
+	a.Nil(err)
+	s := server.(*jobServer)
+	s.mu.Lock()
+	a.Nil(s.addJobs())
+	s.started = true
+	s.mu.Unlock()
+	run := func() time.Duration {
+		s.c.Entry(s.entries["foo"].id).Job.Run()
+		return budget
+	}
+
+	a.True(run() > 50*time.Second)
+
+	// the timeout of the job is changed
+	s.onConfigChange(s.config, cronConfig(time.Minute, 2*time.Second))
+	a.True(run() <= 2*time.Second)
+
+	// the default timeout is changed, but the job keeps its own
+	id := s.entries["foo"].id
+	s.onConfigChange(s.config, cronConfig(5*time.Second, 2*time.Second))
+	a.Equal(id, s.entries["foo"].id)
+
+	// the job follows the default timeout again
+	s.onConfigChange(s.config, cronConfig(5*time.Second, 0))
+	a.NotEqual(id, s.entries["foo"].id)
+	budget = run()
+	a.True(budget > 2*time.Second && budget <= 5*time.Second, budget)
+	a.Len(s.c.Entries(), 1)
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultAsyncQueueSize     = 8192
+	defaultAsyncFlushInterval = time.Second
+)
+
+var droppedEntries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "nfgo",
+	Subsystem: "log",
+	Name:      "dropped_entries_total",
+	Help:      "The number of the log entries dropped since the async logging queue is full.",
+}, []string{"level"})
+
+// DroppedEntriesCollector - the counter of the entries dropped by the async logging, labeled by level.
+// It is registered by the nmetrics server.
+func DroppedEntriesCollector() prometheus.Collector {
+	return droppedEntries
+}
+
+// asyncQueue - the bounded queue of the encoded entries, which are written to their sinks by one goroutine.
+type asyncQueue struct {
+	entries       chan asyncEntry
+	overflow      string
+	flushInterval time.Duration
+	// mu - the entries are sent with the read lock, so that they are not sent after the queue is closed
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+type asyncEntry struct {
+	ws  zapcore.WriteSyncer
+	buf *buffer.Buffer
+	// flushed - it is closed once the entries before it are written and the sinks are synced
+	flushed chan struct{}
+}
+
+func newAsyncQueue(conf *nconf.LogAsyncConfig) *asyncQueue {
+	queueSize := conf.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultAsyncQueueSize
+	}
+	flushInterval := conf.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultAsyncFlushInterval
+	}
+	q := &asyncQueue{
+		entries:       make(chan asyncEntry, queueSize),
+		overflow:      conf.Overflow,
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *asyncQueue) newCore(enc zapcore.Encoder, ws zapcore.WriteSyncer, level zapcore.LevelEnabler) zapcore.Core {
+	return &asyncCore{LevelEnabler: level, enc: enc, ws: ws, queue: q}
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.done)
+	ticker := time.NewTicker(q.flushInterval)
+	defer ticker.Stop()
+
+	// dirty - the sinks written since the last flush
+	dirty := map[zapcore.WriteSyncer]struct{}{}
+	flush := func() {
+		for ws := range dirty {
+			//nolint:errcheck // ignore "sync /dev/stderr: inappropriate ioctl for device"
+			ws.Sync()
+			delete(dirty, ws)
+		}
+	}
+	for {
+		select {
+		case e, ok := <-q.entries:
+			if !ok {
+				flush()
+				return
+			}
+			if e.flushed != nil {
+				flush()
+				close(e.flushed)
+				continue
+			}
+			if _, err := e.ws.Write(e.buf.Bytes()); err != nil {
+				fmt.Fprintf(os.Stderr, "%v async log write error: %v\n", time.Now(), err)
+			}
+			e.buf.Free()
+			dirty[e.ws] = struct{}{}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (q *asyncQueue) enqueue(level zapcore.Level, ws zapcore.WriteSyncer, buf *buffer.Buffer) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		// the loggers derived from the last logger still write after InitLogger closes the queue
+		defer buf.Free()
+		_, err := ws.Write(buf.Bytes())
+		return err
+	}
+
+	e := asyncEntry{ws: ws, buf: buf}
+	switch {
+	case q.overflow == nconf.LogOverflowDropAll, q.overflow == nconf.LogOverflowDropDebug && level <= zapcore.DebugLevel:
+		select {
+		case q.entries <- e:
+		default:
+			droppedEntries.WithLabelValues(level.String()).Inc()
+			buf.Free()
+		}
+	default:
+		q.entries <- e
+	}
+	return nil
+}
+
+// sync - waits until the queued entries are written and the sinks are synced.
+func (q *asyncQueue) sync() error {
+	q.mu.RLock()
+	if q.closed {
+		q.mu.RUnlock()
+		return nil
+	}
+	flushed := make(chan struct{})
+	q.entries <- asyncEntry{flushed: flushed}
+	q.mu.RUnlock()
+	<-flushed
+	return nil
+}
+
+// Close - writes the queued entries and stops the goroutine.
+func (q *asyncQueue) Close() error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.entries)
+	}
+	q.mu.Unlock()
+	<-q.done
+	return nil
+}
+
+// asyncCore - encodes the entries as zapcore.ioCore does, but queues them instead of writing them.
+type asyncCore struct {
+	zapcore.LevelEnabler
+	enc   zapcore.Encoder
+	ws    zapcore.WriteSyncer
+	queue *asyncQueue
+}
+
+func (c *asyncCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+	return &asyncCore{LevelEnabler: c.LevelEnabler, enc: enc, ws: c.ws, queue: c.queue}
+}
+
+func (c *asyncCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *asyncCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	if err := c.queue.enqueue(ent.Level, c.ws, buf); err != nil {
+		return err
+	}
+	// the process may exit or crash after the entries above error level
+	if ent.Level > zapcore.ErrorLevel {
+		return c.Sync()
+	}
+	return nil
+}
+
+func (c *asyncCore) Sync() error {
+	return c.queue.sync()
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// blockingWriter - blocks the first write until it is released.
+type blockingWriter struct {
+	mu      sync.Mutex
+	lines   []string
+	writing chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	if w.writing != nil {
+		close(w.writing)
+		w.writing = nil
+		<-w.release
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lines = append(w.lines, strings.TrimSpace(string(p)))
+	return len(p), nil
+}
+
+func (w *blockingWriter) Sync() error {
+	return nil
+}
+
+func TestAsyncQueueOverflow(t *testing.T) {
+	a := assert.New(t)
+	w := &blockingWriter{writing: make(chan struct{}), release: make(chan struct{})}
+	writing := w.writing
+	q := newAsyncQueue(&nconf.LogAsyncConfig{QueueSize: 1, Overflow: nconf.LogOverflowDropDebug})
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = ""
+	logger := zap.New(q.newCore(zapcore.NewConsoleEncoder(encoderConfig), w, zapcore.DebugLevel))
+	dropped := testutil.ToFloat64(droppedEntries.WithLabelValues("debug"))
+
+	logger.Debug("d1")
+	<-writing
+	logger.Debug("d2")
+	logger.Debug("d3")
+	a.Equal(dropped+1, testutil.ToFloat64(droppedEntries.WithLabelValues("debug")))
+	logged := make(chan struct{})
+	go func() {
+		defer close(logged)
+		// blocks until d2 is written
+		logger.Info("i1")
+	}()
+	close(w.release)
+	<-logged
+	a.Nil(logger.Sync())
+
+	a.Nil(q.Close())
+	logger.Info("i2")
+	a.Equal([]string{"debug\td1", "debug\td2", "info\ti1", "info\ti2"}, w.lines)
+}
+
+func TestAsyncLogger(t *testing.T) {
+	a := assert.New(t)
+	readLog := initFileLogger(t, &nconf.LogConfig{Async: &nconf.LogAsyncConfig{Enabled: true, QueueSize: 16}})
+	for i := 0; i < 100; i++ {
+		Warn("async log")
+	}
+	a.Nil(Sync())
+	a.Equal(100, strings.Count(readLog(), "async log"))
+}
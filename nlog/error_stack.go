@@ -0,0 +1,100 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/nerrors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const defaultErrorStackMaxFrames = 32
+
+// newErrorCore - wraps the core of a sink to render the errors as log.errorStack,
+// nil conf means the default, the core is returned as it is if it is disabled.
+func newErrorCore(core zapcore.Core, conf *nconf.LogErrorStackConfig) zapcore.Core {
+	if conf == nil {
+		conf = &nconf.LogErrorStackConfig{}
+	}
+	if conf.Disabled {
+		return core
+	}
+	level := zapcore.ErrorLevel
+	if conf.Level != "" {
+		level = parseLevel(conf.Level).unWrap()
+	}
+	maxFrames := conf.MaxFrames
+	if maxFrames == 0 {
+		maxFrames = defaultErrorStackMaxFrames
+	}
+	return &errorCore{Core: core, level: level, maxFrames: maxFrames}
+}
+
+// errorCore - the error fields added by With are held until Write, so that they are rendered by the level of the entry.
+type errorCore struct {
+	zapcore.Core
+	errFields []zapcore.Field
+	level     zapcore.Level
+	maxFrames int
+}
+
+func (c *errorCore) With(fields []zapcore.Field) zapcore.Core {
+	errFields := c.errFields[:len(c.errFields):len(c.errFields)]
+	others := make([]zapcore.Field, 0, len(fields))
+	for _, field := range fields {
+		if field.Type == zapcore.ErrorType {
+			errFields = append(errFields, field)
+		} else {
+			others = append(others, field)
+		}
+	}
+	if len(errFields) == len(c.errFields) {
+		return &errorCore{c.Core.With(fields), c.errFields, c.level, c.maxFrames}
+	}
+	return &errorCore{c.Core.With(others), errFields, c.level, c.maxFrames}
+}
+
+func (c *errorCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *errorCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if len(c.errFields) > 0 {
+		fields = append(c.errFields[:len(c.errFields):len(c.errFields)], fields...)
+	}
+	if ent.Level < c.level {
+		return c.Core.Write(ent, fields)
+	}
+	rendered := make([]zapcore.Field, 0, len(fields)+2)
+	for _, field := range fields {
+		err, ok := field.Interface.(error)
+		if field.Type != zapcore.ErrorType || !ok {
+			rendered = append(rendered, field)
+			continue
+		}
+		rendered = append(rendered, zap.String(field.Key, err.Error()), zap.Strings(field.Key+".chain", nerrors.Chain(err)))
+		if stack := nerrors.StackTrace(err); len(stack) > 0 {
+			if len(stack) > c.maxFrames {
+				stack = stack[:c.maxFrames]
+			}
+			rendered = append(rendered, zap.Strings(field.Key+".stack", stack))
+		}
+	}
+	return c.Core.Write(ent, rendered)
+}
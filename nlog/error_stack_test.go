@@ -0,0 +1,53 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorStack(t *testing.T) {
+	a := assert.New(t)
+	readLog := initFileLogger(t, &nconf.LogConfig{Level: "info", ErrorStack: &nconf.LogErrorStackConfig{MaxFrames: 1}})
+
+	err := nerrors.Wrap(nerrors.New("connection refused"), "fail to query")
+	Logger(context.Background()).WithError(err).Error("failed")
+	Logger(context.Background()).WithError(err).Info("retried")
+
+	lines := strings.Split(strings.TrimSpace(readLog()), "\n")
+	a.Len(lines, 2)
+
+	var entry map[string]interface{}
+	a.Nil(json.Unmarshal([]byte(lines[0]), &entry))
+	a.Equal("fail to query: connection refused", entry["error"])
+	a.Equal([]interface{}{"fail to query: connection refused", "connection refused"}, entry["error.chain"])
+	a.Len(entry["error.stack"], 1)
+	a.Contains(entry["error.stack"].([]interface{})[0], "nlog.TestErrorStack")
+	a.Contains(entry["error.stack"].([]interface{})[0], "error_stack_test.go:")
+	a.NotContains(entry, "errorVerbose")
+
+	entry = nil
+	a.Nil(json.Unmarshal([]byte(lines[1]), &entry))
+	a.Equal("fail to query: connection refused", entry["error"])
+	a.NotContains(entry, "error.chain")
+	a.NotContains(entry, "error.stack")
+}
@@ -14,6 +14,29 @@
 
 package nlog
 
+import (
+	"context"
+	"sync"
+
+	"github.com/nf-go/nfgo/ncontext"
+)
+
+var fieldExtractors sync.Map
+
+// FieldExtractor - extracts the value of a field from the ctx, such as the tenantID, ok is false if there is no value.
+type FieldExtractor func(ctx context.Context) (value interface{}, ok bool)
+
+// RegisterFieldExtractor - adds the field key extracted by the extractor to the loggers returned by Logger(ctx)
+// and NamedLogger(ctx, name), the extractor of the same key is replaced.
+func RegisterFieldExtractor(key string, extractor FieldExtractor) {
+	fieldExtractors.Store(key, extractor)
+}
+
+// UnregisterFieldExtractor -
+func UnregisterFieldExtractor(key string) {
+	fieldExtractors.Delete(key)
+}
+
 // Fields -
 type Fields map[string]interface{}
 
@@ -39,3 +62,35 @@ func NewFields(keyVals ...string) Fields {
 	}
 	return fields
 }
+
+// contextFields - the others of the MDC, then the fields of the extractors, then the fixed fields of the MDC,
+// the later ones win if the keys are the same.
+func contextFields(ctx context.Context, mdc ncontext.ROMDC) Fields {
+	fields := Fields{}
+	if mdc != nil {
+		ncontext.RangeOthers(mdc, func(key string, value interface{}) bool {
+			fields[key] = value
+			return true
+		})
+	}
+	fieldExtractors.Range(func(key, extractor interface{}) bool {
+		if value, ok := extractor.(FieldExtractor)(ctx); ok {
+			fields[key.(string)] = value
+		}
+		return true
+	})
+	if mdc != nil {
+		for key, value := range NewFields(
+			"traceID", mdc.TraceID(),
+			"spanID", ncontext.ROTraceOf(mdc).SpanID(),
+			"subjectID", mdc.SubjectID(),
+			"rpcName", mdc.RPCName(),
+			"apiName", mdc.APIName(),
+			"clientIP", mdc.ClientIP(),
+			"clientType", mdc.ClientType(),
+		) {
+			fields[key] = value
+		}
+	}
+	return fields
+}
@@ -15,8 +15,11 @@
 package nlog
 
 import (
+	"context"
 	"testing"
 
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +46,32 @@ func TestNewFields(t *testing.T) {
 	fs = NewFields("k1", "", "k2", "")
 	assert.Equal(t, 0, len(fs))
 }
+
+type tenantKey struct{}
+
+func TestContextFields(t *testing.T) {
+	a := assert.New(t)
+	readLog := initFileLogger(t, &nconf.LogConfig{})
+	RegisterFieldExtractor("tenantID", func(ctx context.Context) (interface{}, bool) {
+		tenantID, ok := ctx.Value(tenantKey{}).(string)
+		return tenantID, ok
+	})
+	defer UnregisterFieldExtractor("tenantID")
+
+	mdc := ncontext.NewMDC()
+	mdc.SetTraceID("t1")
+	mdc.SetOther("orderID", 1001)
+	mdc.SetOther("traceID", "t2")
+	ctx := ncontext.WithMDC(context.WithValue(context.Background(), tenantKey{}, "tn1"), mdc)
+
+	a.Equal(Fields{"orderID": 1001, "tenantID": "tn1", "traceID": "t1"}, contextFields(ctx, mdc))
+	Logger(ctx).Info("with mdc")
+	Logger(context.WithValue(context.Background(), tenantKey{}, "tn2")).Info("without mdc")
+	Logger(context.Background()).Info("without fields")
+	content := readLog()
+	for _, field := range []string{`"msg":"with mdc"`, `"orderID":1001`, `"tenantID":"tn1"`, `"traceID":"t1"`} {
+		a.Contains(content, field)
+	}
+	a.Contains(content, `"msg":"without mdc","app":"foo-app","tenantID":"tn2"}`)
+	a.Contains(content, `"msg":"without fields","app":"foo-app"}`)
+}
@@ -58,22 +58,29 @@ func (l Level) String() string {
 }
 
 func parseLevel(levelStr string) Level {
-	level := InfoLevel
+	level, ok := lookupLevel(levelStr)
+	if !ok {
+		level = InfoLevel
+	}
+	return level
+}
+
+func lookupLevel(levelStr string) (Level, bool) {
 	switch strings.ToLower(levelStr) {
 	case "debug":
-		level = DebugLevel
+		return DebugLevel, true
 	case "info":
-		level = InfoLevel
+		return InfoLevel, true
 	case "warn":
-		level = WarnLevel
+		return WarnLevel, true
 	case "error":
-		level = ErrorLevel
+		return ErrorLevel, true
 	case "panic":
-		level = PanicLevel
+		return PanicLevel, true
 	case "fatal":
-		level = FatalLevel
+		return FatalLevel, true
 	}
-	return level
+	return InfoLevel, false
 }
 
 func (l Level) unWrap() zapcore.Level {
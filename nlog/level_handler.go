@@ -0,0 +1,82 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// LevelHandler - lists the loggers and gets or sets their levels at runtime. It can be mounted on
+// the metrics server by nmetrics.LogLevelOption, or on the web server by gin.WrapH.
+//
+//	GET /                              lists the root logger and the named loggers
+//	GET /?name=ndb                     gets the level of the logger
+//	PUT /?name=ndb {"level": "debug"}  sets the level of the logger, an empty level of a named logger
+//	                                   makes it follow the root level again
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(serveLevel)
+}
+
+func serveLevel(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	switch r.Method {
+	case http.MethodGet:
+		if name == "" {
+			writeLevelJSON(w, http.StatusOK, map[string]interface{}{"loggers": LoggerLevels()})
+			return
+		}
+	case http.MethodPut:
+		var req struct {
+			Level string `json:"level"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeLevelError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %s", err))
+			return
+		}
+		isRoot := name == "" || name == RootLoggerName
+		if req.Level == "" && !isRoot {
+			ResetNamedLevel(name)
+		} else if level, ok := lookupLevel(req.Level); ok {
+			SetNamedLevel(name, level)
+		} else {
+			writeLevelError(w, http.StatusBadRequest, fmt.Sprintf("level %q is not one of debug, info, warn, error, panic, fatal", req.Level))
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		writeLevelError(w, http.StatusMethodNotAllowed, "only GET and PUT are supported")
+		return
+	}
+
+	loggerLevel, ok := levels.lookup(name)
+	if !ok {
+		writeLevelError(w, http.StatusNotFound, fmt.Sprintf("logger %s is not found", name))
+		return
+	}
+	writeLevelJSON(w, http.StatusOK, loggerLevel)
+}
+
+func writeLevelError(w http.ResponseWriter, status int, msg string) {
+	writeLevelJSON(w, status, map[string]string{"error": msg})
+}
+
+func writeLevelJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	//nolint:errcheck
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelHandler(t *testing.T) {
+	a := assert.New(t)
+	server := httptest.NewServer(LevelHandler())
+	defer server.Close()
+	defer ResetNamedLevel("rpc")
+
+	do := func(method string, query string, body string) (int, string) {
+		req, err := http.NewRequest(method, server.URL+query, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, strings.TrimSpace(string(data))
+	}
+
+	status, body := do(http.MethodPut, "?name=rpc", `{"level": "debug"}`)
+	a.Equal(http.StatusOK, status)
+	a.Equal(`{"name":"rpc","level":"debug","explicit":true}`, body)
+	a.True(Named("rpc").IsLevelEnabled(DebugLevel))
+	a.False(IsLevelEnabled(DebugLevel))
+
+	status, body = do(http.MethodGet, "", "")
+	a.Equal(http.StatusOK, status)
+	a.Contains(body, `{"name":"root","level":"info","explicit":true}`)
+	a.Contains(body, `{"name":"rpc","level":"debug","explicit":true}`)
+
+	status, body = do(http.MethodPut, "?name=rpc", `{"level": ""}`)
+	a.Equal(http.StatusOK, status)
+	a.Equal(`{"name":"rpc","level":"info"}`, body)
+
+	status, body = do(http.MethodGet, "?name=not-exist", "")
+	a.Equal(http.StatusNotFound, status)
+	a.Contains(body, "logger not-exist is not found")
+
+	status, body = do(http.MethodPut, "?name=root", `{"level": "verbose"}`)
+	a.Equal(http.StatusBadRequest, status)
+	a.Contains(body, `level \"verbose\" is not one of`)
+
+	status, _ = do(http.MethodDelete, "?name=rpc", "")
+	a.Equal(http.StatusMethodNotAllowed, status)
+}
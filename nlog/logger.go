@@ -16,10 +16,15 @@ package nlog
 
 import (
 	"context"
+	"crypto/subtle"
+	"io"
+	"log/slog"
+	"sync/atomic"
 
 	"github.com/nf-go/nfgo/nconf"
 	"github.com/nf-go/nfgo/ncontext"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // NLogger -
@@ -47,6 +52,11 @@ type NLogger interface {
 var (
 	logger    *nlogger = newDefaultLogger()
 	pkgLogger *nlogger = newPkgLogger(logger)
+	outputs   []io.Closer
+	// traceDebugEnabled - log.traceDebug
+	traceDebugEnabled atomic.Bool
+	// traceDebugToken - log.traceDebugToken
+	traceDebugToken atomic.Pointer[string]
 )
 
 // InitLogger -
@@ -60,32 +70,47 @@ func InitLogger(config *nconf.Config) {
 	zapConfig.InitialFields = fields
 
 	logConf := config.Log
-	setOutput(zapConfig, config)
 	setFormatter(zapConfig, logConf)
 	setLevel(zapConfig, logConf)
+	setSampling(zapConfig, logConf)
+	redactor.Store(NewRedactor(logConf.Redaction))
+	setTraceDebug(logConf)
+	if logConf.SlogDefault {
+		slog.SetDefault(slog.New(NewSlogHandler()))
+	}
 
-	zapLogger := mustNewZapLogger(zapConfig)
+	lastOutputs := outputs
+	cores, closers := newSinkCores(config, zapConfig)
+	zapLogger := mustNewZapLogger(zapConfig, cores...)
 	logger = &nlogger{zapLogger, zapConfig}
 	pkgLogger = newPkgLogger(logger)
+	outputs = closers
+	// the loggers derived from the last logger open the files and the connections again if they still write
+	for _, c := range lastOutputs {
+		//nolint:errcheck
+		c.Close()
+	}
 }
 
-// Logger -
+// Logger - the logger with the fields of the MDC in the ctx including its others,
+// and the fields of the extractors, see RegisterFieldExtractor.
 func Logger(ctx context.Context) NLogger {
-	if ctx != nil {
-		mdc, _ := ncontext.CurrentMDC(ctx)
-		if mdc != nil {
-			fields := NewFields(
-				"traceID", mdc.TraceID(),
-				"subjectID", mdc.SubjectID(),
-				"rpcName", mdc.RPCName(),
-				"apiName", mdc.APIName(),
-				"clientIP", mdc.ClientIP(),
-				"clientType", mdc.ClientType(),
-			)
-			return logger.WithFields(fields)
-		}
+	return withMDC(ctx, logger)
+}
+
+func withMDC(ctx context.Context, l *nlogger) NLogger {
+	if ctx == nil {
+		return l
 	}
-	return logger
+	mdc, _ := ncontext.CurrentMDC(ctx)
+	if mdc != nil && ncontext.ROTraceOf(mdc).Debug() && traceDebugEnabled.Load() {
+		l = l.traceDebug()
+	}
+	fields := contextFields(ctx, mdc)
+	if len(fields) == 0 {
+		return l
+	}
+	return l.WithFields(fields)
 }
 
 type nlogger struct {
@@ -108,9 +133,56 @@ func newPkgLogger(logger *nlogger) *nlogger {
 	}
 }
 
+// named - the child logger named name which is filtered by level instead of the level of l.
+func (l *nlogger) named(name string, level zap.AtomicLevel) *nlogger {
+	config := *l.Config
+	config.Level = level
+	zapLogger := l.Desugar().Named(name).WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if c, ok := core.(*levelCore); ok {
+			return c.withLevel(level)
+		}
+		return core
+	}))
+	return &nlogger{zapLogger.Sugar(), &config}
+}
+
+// traceDebug - the logger of the debug trace which logs at debug level without sampling.
+func (l *nlogger) traceDebug() *nlogger {
+	level := zap.NewAtomicLevelAt(zap.DebugLevel)
+	config := *l.Config
+	config.Level = level
+	zapLogger := l.Desugar().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if c, ok := core.(*levelCore); ok {
+			return c.withoutSampling(level)
+		}
+		return core
+	}))
+	return &nlogger{zapLogger.Sugar(), &config}
+}
+
+func setTraceDebug(logConf *nconf.LogConfig) {
+	traceDebugEnabled.Store(logConf.TraceDebug)
+	token := logConf.TraceDebugToken
+	traceDebugToken.Store(&token)
+}
+
+// IsTraceDebugHeader - whether the X-Debug header flags the trace as debug, it must be log.traceDebugToken.
+// It is false if log.traceDebug is disabled or the token is not set.
+func IsTraceDebugHeader(value string) bool {
+	token := TraceDebugHeader()
+	return token != "" && subtle.ConstantTimeCompare([]byte(value), []byte(token)) == 1
+}
+
+// TraceDebugHeader - the X-Debug header which flags the trace as debug in the downstream services,
+// it is empty if log.traceDebug is disabled.
+func TraceDebugHeader() string {
+	if token := traceDebugToken.Load(); token != nil && traceDebugEnabled.Load() {
+		return *token
+	}
+	return ""
+}
+
 func (l *nlogger) IsLevelEnabled(level Level) bool {
-	//nolint:errcheck // ignore errcheck!
-	l.Sync()
 	return l.Config.Level.Enabled(level.unWrap())
 }
 
@@ -118,14 +190,23 @@ func (l *nlogger) WithError(err error) NLogger {
 	return &nlogger{l.With("error", err), l.Config}
 }
 
+// WithField - the value is redacted by log.redaction.
 func (l *nlogger) WithField(key string, value interface{}) NLogger {
+	value, ok := redactor.Load().RedactField(key, value)
+	if !ok {
+		return l
+	}
 	return &nlogger{l.With(key, value), l.Config}
 }
 
+// WithFields - the values are redacted by log.redaction.
 func (l *nlogger) WithFields(fields Fields) NLogger {
+	r := redactor.Load()
 	args := make([]interface{}, 0, len(fields))
 	for key, value := range fields {
-		args = append(args, key, value)
+		if value, ok := r.RedactField(key, value); ok {
+			args = append(args, key, value)
+		}
 	}
 	return &nlogger{l.With(args...), l.Config}
 }
@@ -201,8 +282,47 @@ func Panic(args ...interface{}) {
 
 // SetLevel - alters the logging level.
 // It lets you safely change the log level of a tree of loggers (the root logger and any children created by adding context) at runtime.
+// The named loggers follow it unless their levels are set by SetNamedLevel.
 func SetLevel(level Level) {
-	pkgLogger.Config.Level.SetLevel(level.unWrap())
+	levels.setRoot(level)
+}
+
+// OnConfigChange - applies the log config changes which are safe to be applied at runtime, such as the log level.
+// It can be registered as a subscriber of nconf.ConfigWatcher.
+func OnConfigChange(old, new *nconf.Config) {
+	if new.Log == nil {
+		return
+	}
+	if old.Log == nil || old.Log.Level != new.Log.Level {
+		SetLevel(parseLevel(new.Log.Level))
+	}
+	redactor.Store(NewRedactor(new.Log.Redaction))
+	setTraceDebug(new.Log)
+	for name, level := range new.Log.Levels {
+		if old.Log == nil || old.Log.Levels[name] != level {
+			setNamedLevel(name, level)
+		}
+	}
+	if old.Log != nil {
+		for name := range old.Log.Levels {
+			if _, ok := new.Log.Levels[name]; !ok {
+				ResetNamedLevel(name)
+			}
+		}
+	}
+}
+
+// ReplaceCore - replaces the loggers with the ones writing to the core without sampling, the levels and the fields
+// such as app are kept. It returns a func which restores the last loggers, see nlogtest.
+func ReplaceCore(core zapcore.Core) (restore func()) {
+	lastLogger, lastPkgLogger := logger, pkgLogger
+	zapConfig := *logger.Config
+	zapConfig.Sampling = nil
+	logger = &nlogger{mustNewZapLogger(&zapConfig, core), &zapConfig}
+	pkgLogger = newPkgLogger(logger)
+	return func() {
+		logger, pkgLogger = lastLogger, lastPkgLogger
+	}
 }
 
 // Sync - Sync calls the underlying Core's Sync method, flushing any buffered log entries.
@@ -16,9 +16,12 @@ package nlog
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInitLogger(t *testing.T) {
@@ -37,3 +40,68 @@ func TestInitLogger(t *testing.T) {
 	logger.Info("hello")
 	logger.Warn("world")
 }
+
+func TestOnConfigChange(t *testing.T) {
+	a := assert.New(t)
+	old := &nconf.Config{Log: &nconf.LogConfig{Level: "info"}}
+	new := &nconf.Config{Log: &nconf.LogConfig{Level: "debug"}}
+	OnConfigChange(old, new)
+	a.True(IsLevelEnabled(DebugLevel))
+	OnConfigChange(new, old)
+	a.False(IsLevelEnabled(DebugLevel))
+}
+
+func TestLogSampling(t *testing.T) {
+	a := assert.New(t)
+	readLog := initFileLogger(t, &nconf.LogConfig{Sampling: &nconf.LogSamplingConfig{Initial: 10, Thereafter: 50}})
+	for i := 0; i < 200; i++ {
+		Info("sampled")
+	}
+	// the 1st-10th, 60th, 110th and 160th
+	a.Equal(13, strings.Count(readLog(), "sampled"))
+
+	readLog = initFileLogger(t, &nconf.LogConfig{Sampling: &nconf.LogSamplingConfig{Disabled: true}})
+	for i := 0; i < 200; i++ {
+		Info("not sampled")
+	}
+	a.Equal(200, strings.Count(readLog(), "not sampled"))
+}
+
+func TestIsTraceDebugHeader(t *testing.T) {
+	a := assert.New(t)
+	initFileLogger(t, &nconf.LogConfig{Level: "info", TraceDebug: true, TraceDebugToken: "t0ken"})
+	a.True(IsTraceDebugHeader("t0ken"))
+	a.False(IsTraceDebugHeader("true"))
+	a.False(IsTraceDebugHeader(""))
+	a.Equal("t0ken", TraceDebugHeader())
+
+	OnConfigChange(&nconf.Config{Log: &nconf.LogConfig{Level: "info"}}, &nconf.Config{Log: &nconf.LogConfig{Level: "info", TraceDebugToken: "t0ken"}})
+	a.False(IsTraceDebugHeader("t0ken"))
+	a.Empty(TraceDebugHeader())
+}
+
+func TestTraceDebug(t *testing.T) {
+	a := assert.New(t)
+	readLog := initFileLogger(t, &nconf.LogConfig{Level: "info", TraceDebug: true})
+	mdc := ncontext.NewMDC()
+	mdc.SetTraceID("t1")
+	ncontext.TraceOf(mdc).SetDebug(true)
+	ctx := ncontext.WithMDC(context.Background(), mdc)
+
+	logger := Logger(ctx)
+	a.True(logger.IsLevelEnabled(DebugLevel))
+	a.False(IsLevelEnabled(DebugLevel))
+	for i := 0; i < 200; i++ {
+		logger.Debug("debug trace")
+		Logger(context.Background()).Info("other trace")
+	}
+	NamedLogger(ctx, "ndb").Debug("named debug trace")
+	content := readLog()
+	a.Equal(200, strings.Count(content, `"debug trace"`))
+	// the 1st-100th and 200th
+	a.Equal(101, strings.Count(content, "other trace"))
+	a.Contains(content, `"logger":"ndb","msg":"named debug trace","app":"foo-app","traceID":"t1"`)
+
+	traceDebugEnabled.Store(false)
+	a.False(Logger(ctx).IsLevelEnabled(DebugLevel))
+}
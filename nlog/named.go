@@ -0,0 +1,229 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RootLoggerName - the name of the root logger, see LoggerLevels and LevelHandler.
+const RootLoggerName = "root"
+
+var levels = &levelRegistry{
+	root:  zap.NewAtomicLevelAt(zap.InfoLevel),
+	named: map[string]*namedLogger{},
+}
+
+// LoggerLevel - the level of a logger, Explicit is false if it follows the root level.
+type LoggerLevel struct {
+	Name     string `json:"name"`
+	Level    string `json:"level"`
+	Explicit bool   `json:"explicit,omitempty"`
+}
+
+// Named - returns the named logger, such as Named("ndb"), its level can be set separately by SetNamedLevel.
+// Like Logger, it should be called after InitLogger.
+func Named(name string) NLogger {
+	return levels.logger(name, logger)
+}
+
+// NamedLogger - the named logger with the fields of the MDC in the ctx, see Logger.
+func NamedLogger(ctx context.Context, name string) NLogger {
+	return withMDC(ctx, levels.logger(name, logger))
+}
+
+// SetNamedLevel - sets the level of the named logger, it does not follow the root level any more.
+func SetNamedLevel(name string, level Level) {
+	levels.set(name, level)
+}
+
+// ResetNamedLevel - makes the level of the named logger follow the root level again.
+func ResetNamedLevel(name string) {
+	levels.reset(name)
+}
+
+// NamedLevel - the level of the named logger, it follows SetLevel, SetNamedLevel and ResetNamedLevel.
+func NamedLevel(name string) AtomicLevel {
+	return AtomicLevel{levels.atomicLevel(name)}
+}
+
+// AtomicLevel - the level of a logger which can be read without locking, so that it can be checked per call.
+type AtomicLevel struct {
+	level zap.AtomicLevel
+}
+
+// Level - the current level.
+func (l AtomicLevel) Level() Level {
+	return Level(l.level.Level())
+}
+
+// LoggerLevels - the levels of the root logger and the named loggers sorted by name.
+func LoggerLevels() []LoggerLevel {
+	return levels.list()
+}
+
+// levelRegistry - the levels of the root logger and the named loggers, they survive InitLogger.
+type levelRegistry struct {
+	mu    sync.Mutex
+	root  zap.AtomicLevel
+	named map[string]*namedLogger
+}
+
+type namedLogger struct {
+	level    zap.AtomicLevel
+	explicit bool
+	// logger - built from base, it is rebuilt once the root logger is replaced by InitLogger.
+	base   *nlogger
+	logger *nlogger
+}
+
+func (r *levelRegistry) logger(name string, base *nlogger) *nlogger {
+	if name == "" || name == RootLoggerName {
+		return base
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.get(name)
+	if n.base != base {
+		n.base, n.logger = base, base.named(name, n.level)
+	}
+	return n.logger
+}
+
+func (r *levelRegistry) atomicLevel(name string) zap.AtomicLevel {
+	if name == "" || name == RootLoggerName {
+		return r.root
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.get(name).level
+}
+
+func (r *levelRegistry) get(name string) *namedLogger {
+	n, ok := r.named[name]
+	if !ok {
+		n = &namedLogger{level: zap.NewAtomicLevelAt(r.root.Level())}
+		r.named[name] = n
+	}
+	return n
+}
+
+func (r *levelRegistry) setRoot(level Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.root.SetLevel(level.unWrap())
+	for _, n := range r.named {
+		if !n.explicit {
+			n.level.SetLevel(level.unWrap())
+		}
+	}
+}
+
+func (r *levelRegistry) set(name string, level Level) {
+	if name == "" || name == RootLoggerName {
+		r.setRoot(level)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.get(name)
+	n.explicit = true
+	n.level.SetLevel(level.unWrap())
+}
+
+func (r *levelRegistry) reset(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n, ok := r.named[name]; ok {
+		n.explicit = false
+		n.level.SetLevel(r.root.Level())
+	}
+}
+
+func (r *levelRegistry) lookup(name string) (LoggerLevel, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if name == "" || name == RootLoggerName {
+		return LoggerLevel{Name: RootLoggerName, Level: Level(r.root.Level()).String(), Explicit: true}, true
+	}
+	n, ok := r.named[name]
+	if !ok {
+		return LoggerLevel{}, false
+	}
+	return LoggerLevel{Name: name, Level: Level(n.level.Level()).String(), Explicit: n.explicit}, true
+}
+
+func (r *levelRegistry) list() []LoggerLevel {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.named))
+	for name := range r.named {
+		names = append(names, name)
+	}
+	r.mu.Unlock()
+	sort.Strings(names)
+
+	loggerLevels := make([]LoggerLevel, 0, len(names)+1)
+	for _, name := range append([]string{RootLoggerName}, names...) {
+		if l, ok := r.lookup(name); ok {
+			loggerLevels = append(loggerLevels, l)
+		}
+	}
+	return loggerLevels
+}
+
+// levelCore - filters the entries by the level of the logger. The cores under it enable all the levels,
+// so that the named loggers sharing them can have their own levels.
+type levelCore struct {
+	zapcore.Core
+	// unsampled - the core without sampling for the debug traces, nil if Core is not sampled.
+	unsampled zapcore.Core
+	level     zapcore.LevelEnabler
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	unsampled := c.unsampled
+	if unsampled != nil {
+		unsampled = unsampled.With(fields)
+	}
+	return &levelCore{c.Core.With(fields), unsampled, c.level}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.level.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+func (c *levelCore) withLevel(level zapcore.LevelEnabler) *levelCore {
+	return &levelCore{c.Core, c.unsampled, level}
+}
+
+// withoutSampling - the core at the level which keeps all the entries.
+func (c *levelCore) withoutSampling(level zapcore.LevelEnabler) *levelCore {
+	if c.unsampled == nil {
+		return c.withLevel(level)
+	}
+	return &levelCore{c.unsampled, nil, level}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func initFileLogger(t *testing.T, logConf *nconf.LogConfig) func() string {
+	logPath := t.TempDir()
+	logConf.Format = "json"
+	logConf.Sinks = []*nconf.LogSinkConfig{{Type: nconf.LogSinkFile, LogPath: logPath, LogFilename: "app.log"}}
+	InitLogger(&nconf.Config{App: &nconf.AppConfig{Name: "foo-app"}, Log: logConf})
+	t.Cleanup(func() {
+		InitLogger(&nconf.Config{App: &nconf.AppConfig{}, Log: &nconf.LogConfig{}})
+	})
+	return func() string {
+		files, _ := filepath.Glob(filepath.Join(logPath, "*", "app.log"))
+		if len(files) == 0 {
+			return ""
+		}
+		data, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+}
+
+func TestNamedLogger(t *testing.T) {
+	a := assert.New(t)
+	readLog := initFileLogger(t, &nconf.LogConfig{Level: "info", Levels: map[string]string{"gorm": "debug"}})
+	defer ResetNamedLevel("gorm")
+
+	Named("gorm").Debug("gorm debug")
+	Named("web").Debug("web debug")
+	Debug("root debug")
+	Named("web").Info("web info")
+
+	content := readLog()
+	a.Contains(content, `"logger":"gorm","msg":"gorm debug"`)
+	a.Contains(content, `"logger":"web","msg":"web info"`)
+	a.NotContains(content, "web debug")
+	a.NotContains(content, "root debug")
+	a.True(Named("gorm").IsLevelEnabled(DebugLevel))
+	a.False(Named("web").IsLevelEnabled(DebugLevel))
+
+	SetLevel(WarnLevel)
+	a.Equal("warn", Named("web").LevelString())
+	a.Equal("debug", Named("gorm").LevelString())
+	ResetNamedLevel("gorm")
+	a.Equal("warn", Named("gorm").LevelString())
+	SetLevel(InfoLevel)
+
+	loggerLevels := LoggerLevels()
+	a.Equal(LoggerLevel{Name: RootLoggerName, Level: "info", Explicit: true}, loggerLevels[0])
+	a.Contains(loggerLevels, LoggerLevel{Name: "gorm", Level: "info"})
+	a.Contains(loggerLevels, LoggerLevel{Name: "web", Level: "info"})
+}
+
+func TestNamedLevel(t *testing.T) {
+	a := assert.New(t)
+	level := NamedLevel("sql")
+	a.Equal(InfoLevel, level.Level())
+
+	SetNamedLevel("sql", DebugLevel)
+	a.Equal(DebugLevel, level.Level())
+	ResetNamedLevel("sql")
+	SetLevel(WarnLevel)
+	a.Equal(WarnLevel, level.Level())
+	a.Equal(WarnLevel, NamedLevel(RootLoggerName).Level())
+	SetLevel(InfoLevel)
+	a.Equal(InfoLevel, level.Level())
+}
+
+func TestNamedLoggerOnConfigChange(t *testing.T) {
+	a := assert.New(t)
+	old := &nconf.Config{Log: &nconf.LogConfig{Level: "info", Levels: map[string]string{"cron": "debug"}}}
+	new := &nconf.Config{Log: &nconf.LogConfig{Level: "info", Levels: map[string]string{"cron": "error"}}}
+	OnConfigChange(&nconf.Config{}, old)
+	a.Equal("debug", Named("cron").LevelString())
+	OnConfigChange(old, new)
+	a.Equal("error", Named("cron").LevelString())
+	OnConfigChange(new, &nconf.Config{Log: &nconf.LogConfig{Level: "info"}})
+	a.Equal("info", Named("cron").LevelString())
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"context"
+
+	"github.com/nf-go/nfgo/ncontext"
+)
+
+func init() {
+	ncontext.SetPanicHandler(logPanic)
+}
+
+// logPanic - logs the panics recovered by ncontext.Recover with the MDC of ctx.
+func logPanic(ctx context.Context, r interface{}, stack []byte) {
+	Logger(ctx).WithField("stack", string(stack)).Errorf("panic recovered: %v", r)
+}
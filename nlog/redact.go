@@ -0,0 +1,300 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/nf-go/nfgo/nconf"
+)
+
+// redactor - the redactor of log.redaction, it is set by InitLogger, nil means nothing is redacted.
+var redactor atomic.Pointer[Redactor]
+
+// RedactionEnabled - whether any redaction rule is configured by log.redaction.
+func RedactionEnabled() bool {
+	return redactor.Load() != nil
+}
+
+// RedactJSON - redacts the JSON by log.redaction. The data which is not a valid JSON, such as a truncated body or
+// a text body, is redacted by the field names that look like keys in it, see Redactor.RedactJSON.
+func RedactJSON(data []byte) []byte {
+	return redactor.Load().RedactJSON(data)
+}
+
+// RedactQuery - redacts the values of the query string or the url-encoded form by log.redaction.
+func RedactQuery(rawQuery string) string {
+	return redactor.Load().RedactQuery(rawQuery)
+}
+
+// Redactor - redacts the sensitive fields by the rules, see nconf.LogRedactionRule. A nil Redactor redacts nothing.
+type Redactor struct {
+	rules []*redactRule
+}
+
+type redactRule struct {
+	// keyRegexp - matches the keys of the names and the last segments of the paths followed by : or = and a value
+	keyRegexp *regexp.Regexp
+	// names - the lower-cased patterns of the field names
+	names []string
+	// paths - the lower-cased patterns of the segments of the JSON paths
+	paths    [][]string
+	strategy string
+	prefix   int
+	suffix   int
+	hashKey  []byte
+}
+
+// NewRedactor - returns nil if there is no rule.
+func NewRedactor(rules []*nconf.LogRedactionRule) *Redactor {
+	r := &Redactor{}
+	for _, rule := range rules {
+		if rule == nil || len(rule.Fields) == 0 {
+			continue
+		}
+		rr := &redactRule{strategy: rule.Strategy, prefix: rule.KeepPrefix, suffix: rule.KeepSuffix, hashKey: []byte(rule.HashKey)}
+		if rr.prefix == 0 && rr.suffix == 0 {
+			rr.prefix, rr.suffix = 3, 4
+		}
+		// the hash without a key can be brute-forced, see nconf.LogRedactHash
+		if rr.strategy == nconf.LogRedactHash && len(rr.hashKey) == 0 {
+			rr.strategy = nconf.LogRedactMask
+		}
+		keyPatterns := make([]string, 0, len(rule.Fields))
+		for _, field := range rule.Fields {
+			field = strings.TrimPrefix(strings.ToLower(field), "$.")
+			segs := strings.Split(field, ".")
+			if len(segs) > 1 {
+				rr.paths = append(rr.paths, segs)
+			} else {
+				rr.names = append(rr.names, field)
+			}
+			keyPatterns = append(keyPatterns, globToRegexp(segs[len(segs)-1]))
+		}
+		rr.keyRegexp = regexp.MustCompile(`(?i)(^|[^\w-])("?(?:` + strings.Join(keyPatterns, "|") +
+			`)"?\s*[:=]\s*)("(?:[^"\\]|\\.)*"?|[^\s,;&}\]]*)`)
+		r.rules = append(r.rules, rr)
+	}
+	if len(r.rules) == 0 {
+		return nil
+	}
+	return r
+}
+
+// RedactField - redacts the value of the field key, ok is false if the field should be removed.
+func (r *Redactor) RedactField(key string, value interface{}) (redacted interface{}, ok bool) {
+	if rule := r.match([]string{strings.ToLower(key)}); rule != nil {
+		return rule.redact(value)
+	}
+	return value, true
+}
+
+// RedactJSON - see the package func RedactJSON. The data which is not a valid JSON object or array never passes as
+// it is: the values after the keys matching the field names, or the last segments of the paths, are masked
+// whatever the strategy, e.g. "password":"p1 of a truncated JSON, password=p1 or password: p1 of a text.
+func (r *Redactor) RedactJSON(data []byte) []byte {
+	if r == nil {
+		return data
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') || !json.Valid(trimmed) {
+		return r.redactText(data)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return r.redactText(data)
+	}
+	v, changed := r.redactJSONValue(nil, v)
+	if !changed {
+		return data
+	}
+	redacted, err := json.Marshal(v)
+	if err != nil {
+		return r.redactText(data)
+	}
+	return redacted
+}
+
+// redactText - masks the values after the keys of the rules in the text.
+func (r *Redactor) redactText(data []byte) []byte {
+	for _, rule := range r.rules {
+		data = rule.keyRegexp.ReplaceAllFunc(data, func(match []byte) []byte {
+			groups := rule.keyRegexp.FindSubmatch(match)
+			value := nconf.RedactedSecret
+			if bytes.HasPrefix(groups[3], []byte(`"`)) {
+				value = `"` + value + `"`
+			}
+			redacted := make([]byte, 0, len(groups[1])+len(groups[2])+len(value))
+			redacted = append(append(redacted, groups[1]...), groups[2]...)
+			return append(redacted, value...)
+		})
+	}
+	return data
+}
+
+// globToRegexp - converts the pattern of path.Match to a regexp which matches a key.
+func globToRegexp(pattern string) string {
+	var sb strings.Builder
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			sb.WriteString(`[\w.-]*`)
+		case '?':
+			sb.WriteString(`[\w.-]`)
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
+
+func (r *Redactor) redactJSONValue(keyPath []string, v interface{}) (interface{}, bool) {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			valuePath := append(keyPath[:len(keyPath):len(keyPath)], strings.ToLower(key))
+			if rule := r.match(valuePath); rule != nil {
+				if redacted, ok := rule.redact(value); ok {
+					v[key] = redacted
+				} else {
+					delete(v, key)
+				}
+				changed = true
+			} else if redacted, c := r.redactJSONValue(valuePath, value); c {
+				v[key] = redacted
+				changed = true
+			}
+		}
+	case []interface{}:
+		// the arrays are transparent in the paths
+		for i, value := range v {
+			if redacted, c := r.redactJSONValue(keyPath, value); c {
+				v[i] = redacted
+				changed = true
+			}
+		}
+	}
+	return v, changed
+}
+
+// RedactQuery - see the package func RedactQuery.
+func (r *Redactor) RedactQuery(rawQuery string) string {
+	if r == nil || rawQuery == "" {
+		return rawQuery
+	}
+	pairs := strings.Split(rawQuery, "&")
+	redactedPairs := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		key, value, _ := strings.Cut(pair, "=")
+		if k, err := url.QueryUnescape(key); err == nil {
+			key = k
+		}
+		rule := r.match([]string{strings.ToLower(key)})
+		if rule == nil {
+			redactedPairs = append(redactedPairs, pair)
+			continue
+		}
+		if v, err := url.QueryUnescape(value); err == nil {
+			value = v
+		}
+		if redacted, ok := rule.redact(value); ok {
+			redactedPairs = append(redactedPairs, key+"="+fmt.Sprint(redacted))
+		}
+	}
+	return strings.Join(redactedPairs, "&")
+}
+
+// match - the first rule which matches the last segment of the key path by name or the whole key path by path.
+func (r *Redactor) match(keyPath []string) *redactRule {
+	if r == nil {
+		return nil
+	}
+	for _, rule := range r.rules {
+		for _, name := range rule.names {
+			if ok, _ := path.Match(name, keyPath[len(keyPath)-1]); ok {
+				return rule
+			}
+		}
+		for _, segs := range rule.paths {
+			if matchSegments(segs, keyPath) {
+				return rule
+			}
+		}
+	}
+	return nil
+}
+
+func matchSegments(patterns []string, segs []string) bool {
+	if len(patterns) != len(segs) {
+		return false
+	}
+	for i, pattern := range patterns {
+		if ok, _ := path.Match(pattern, segs[i]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (rule *redactRule) redact(value interface{}) (interface{}, bool) {
+	if value == nil {
+		return nil, true
+	}
+	switch rule.strategy {
+	case nconf.LogRedactRemove:
+		return nil, false
+	case nconf.LogRedactHash:
+		mac := hmac.New(sha256.New, rule.hashKey)
+		mac.Write([]byte(redactString(value)))
+		return "hmac:" + hex.EncodeToString(mac.Sum(nil)[:8]), true
+	case nconf.LogRedactPartial:
+		chars := []rune(redactString(value))
+		if len(chars) <= rule.prefix+rule.suffix {
+			return nconf.RedactedSecret, true
+		}
+		return string(chars[:rule.prefix]) + strings.Repeat("*", len(chars)-rule.prefix-rule.suffix) +
+			string(chars[len(chars)-rule.suffix:]), true
+	}
+	return nconf.RedactedSecret, true
+}
+
+func redactString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	case map[string]interface{}, []interface{}:
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRedactor() *Redactor {
+	return NewRedactor([]*nconf.LogRedactionRule{
+		{Fields: []string{"password", "*token"}},
+		{Fields: []string{"phone"}, Strategy: nconf.LogRedactPartial},
+		{Fields: []string{"$.user.idCard"}, Strategy: nconf.LogRedactPartial, KeepPrefix: 2, KeepSuffix: 2},
+		{Fields: []string{"email"}, Strategy: nconf.LogRedactHash, HashKey: "k1"},
+		{Fields: []string{"users.address"}, Strategy: nconf.LogRedactRemove},
+	})
+}
+
+func TestRedactJSON(t *testing.T) {
+	a := assert.New(t)
+	r := newTestRedactor()
+
+	data := []byte(`{"user":{"name":"foo","idCard":"110101199001011234","Password":"p@ss"},"accessToken":"t1",` +
+		`"users":[{"phone":"13800001234","address":"bar","idCard":"1234567"}],"email":"foo@bar.com","age":18}`)
+	a.JSONEq(`{"user":{"name":"foo","idCard":"11**************34","Password":"******"},"accessToken":"******",`+
+		`"users":[{"phone":"138****1234","idCard":"1234567"}],"email":"hmac:2e9d026dab61fa31","age":18}`,
+		string(r.RedactJSON(data)))
+
+	notChanged := []byte(`{ "name": "foo" }`)
+	a.Equal(notChanged, r.RedactJSON(notChanged))
+	a.Equal(data, (*Redactor)(nil).RedactJSON(data))
+	a.Nil(NewRedactor(nil))
+
+	unkeyed := NewRedactor([]*nconf.LogRedactionRule{{Fields: []string{"email"}, Strategy: nconf.LogRedactHash}})
+	a.Equal(`{"email":"******"}`, string(unkeyed.RedactJSON([]byte(`{"email":"foo@bar.com"}`))))
+}
+
+func TestRedactInvalidJSON(t *testing.T) {
+	a := assert.New(t)
+	r := newTestRedactor()
+
+	truncated := `{"user":{"name":"foo","Password":"p@ss\"word"},"accessToken":"t1","phone":"13800`
+	a.Equal(`{"user":{"name":"foo","Password":"******"},"accessToken":"******","phone":"******"`,
+		string(r.RedactJSON([]byte(truncated))))
+	a.Equal(`{"password": "******"`, string(r.RedactJSON([]byte(`{"password": "p1`))))
+
+	text := "login password=p1 phone: 13800001234, user.idCard=110101199001011234&email=foo@bar.com; name=foo"
+	a.Equal("login password=****** phone: ******, user.idCard=******&email=******; name=foo",
+		string(r.RedactJSON([]byte(text))))
+	a.Equal("no secrets here", string(r.RedactJSON([]byte("no secrets here"))))
+	a.Equal(`{"password":"******"} trailing`, string(r.RedactJSON([]byte(`{"password":"p1"} trailing`))))
+}
+
+func TestRedactQueryAndFields(t *testing.T) {
+	a := assert.New(t)
+	r := newTestRedactor()
+	a.Equal("name=foo&password=******&phone=138****1234", r.RedactQuery("name=foo&password=p%40ss&phone=13800001234"))
+
+	value, ok := r.RedactField("Phone", 13800001234)
+	a.True(ok)
+	a.Equal("138****1234", value)
+	_, ok = r.RedactField("address", "bar")
+	a.True(ok)
+	value, ok = r.RedactField("phone", "1234")
+	a.True(ok)
+	a.Equal(nconf.RedactedSecret, value)
+}
+
+func TestLoggerRedaction(t *testing.T) {
+	a := assert.New(t)
+	readLog := initFileLogger(t, &nconf.LogConfig{Redaction: []*nconf.LogRedactionRule{
+		{Fields: []string{"password"}},
+		{Fields: []string{"address"}, Strategy: nconf.LogRedactRemove},
+	}})
+	a.True(RedactionEnabled())
+	Logger(context.Background()).WithField("password", "p1").WithFields(Fields{"user": "foo", "address": "bar"}).Info("login")
+	a.Contains(readLog(), `"msg":"login","app":"foo-app","password":"******","user":"foo"}`)
+	a.Equal(`{"password":"******"}`, string(RedactJSON([]byte(`{"password":"p1"}`))))
+}
@@ -0,0 +1,268 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"compress/gzip"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nf-go/nfgo/nconf"
+)
+
+const (
+	monthDirFormat   = "200601"
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	compressSuffix   = ".gz"
+	megabyte         = 1024 * 1024
+)
+
+// rotateWriter - writes {logPath}/{yyyyMM}/{filename}, and rotates it when the month changes,
+// or as the rotation config says. The rotated files are compressed and removed in the background.
+type rotateWriter struct {
+	logPath  string
+	filename string
+	conf     nconf.LogRotationConfig
+	now      func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openTime time.Time
+
+	millMu sync.Mutex
+	millWG sync.WaitGroup
+}
+
+func newRotateWriter(logPath string, filename string, conf *nconf.LogRotationConfig) *rotateWriter {
+	w := &rotateWriter{
+		logPath:  logPath,
+		filename: filename,
+		now:      time.Now,
+	}
+	if conf != nil {
+		w.conf = *conf
+	}
+	return w
+}
+
+func (w *rotateWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	if w.file == nil {
+		if err := w.open(now); err != nil {
+			return 0, err
+		}
+	}
+	if w.shouldRotate(now, len(p)) {
+		if err := w.rotate(now); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotateWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Sync()
+}
+
+// Close - closes the file and waits for the background work, a later Write opens the file again.
+func (w *rotateWriter) Close() error {
+	w.mu.Lock()
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.mu.Unlock()
+	w.millWG.Wait()
+	return err
+}
+
+func (w *rotateWriter) shouldRotate(now time.Time, n int) bool {
+	if now.Format(monthDirFormat) != w.openTime.Format(monthDirFormat) {
+		return true
+	}
+	if w.conf.Daily && now.YearDay() != w.openTime.YearDay() {
+		return true
+	}
+	return w.conf.MaxSize > 0 && w.size > 0 && w.size+int64(n) > int64(w.conf.MaxSize)*megabyte
+}
+
+func (w *rotateWriter) filePath(t time.Time) string {
+	return filepath.Join(w.logPath, t.Format(monthDirFormat), w.filename)
+}
+
+func (w *rotateWriter) open(now time.Time) error {
+	path := w.filePath(now)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		//nolint:errcheck
+		file.Close()
+		return err
+	}
+	w.file = file
+	w.size = info.Size()
+	w.openTime = now
+	if w.size > 0 {
+		// the file is left by the last process, so it rotates on the day it was written
+		w.openTime = info.ModTime()
+	}
+	return nil
+}
+
+func (w *rotateWriter) rotate(now time.Time) error {
+	path := w.file.Name()
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+	if err := os.Rename(path, w.backupPath(path, now)); err != nil {
+		return err
+	}
+	if err := w.open(now); err != nil {
+		return err
+	}
+
+	w.millWG.Add(1)
+	go func() {
+		defer w.millWG.Done()
+		w.mill(now)
+	}()
+	return nil
+}
+
+// backupPath - {dir}/{name}{ext} => {dir}/{name}-{rotation time}{ext}
+func (w *rotateWriter) backupPath(path string, t time.Time) string {
+	ext := filepath.Ext(w.filename)
+	name := strings.TrimSuffix(w.filename, ext)
+	return filepath.Join(filepath.Dir(path), name+"-"+t.Format(backupTimeFormat)+ext)
+}
+
+type backupFile struct {
+	path string
+	time time.Time
+}
+
+// backups - returns the rotated files in all the month directories, the newest first.
+func (w *rotateWriter) backups() ([]backupFile, error) {
+	ext := filepath.Ext(w.filename)
+	prefix := strings.TrimSuffix(w.filename, ext) + "-"
+	paths, err := filepath.Glob(filepath.Join(w.logPath, "*", prefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	var backups []backupFile
+	for _, path := range paths {
+		ts := strings.TrimPrefix(filepath.Base(path), prefix)
+		ts = strings.TrimSuffix(strings.TrimSuffix(ts, compressSuffix), ext)
+		t, err := time.ParseInLocation(backupTimeFormat, ts, time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backupFile{path: path, time: t})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].time.After(backups[j].time)
+	})
+	return backups, nil
+}
+
+// mill - removes the rotated files beyond the retention, and compresses the rest.
+func (w *rotateWriter) mill(now time.Time) {
+	w.millMu.Lock()
+	defer w.millMu.Unlock()
+
+	backups, err := w.backups()
+	if err != nil {
+		log.Printf("nlog: fail to list the rotated log files: %s", err)
+		return
+	}
+	dirs := map[string]struct{}{}
+	for i, backup := range backups {
+		expired := w.conf.MaxAge > 0 && now.Sub(backup.time) > w.conf.MaxAge
+		if expired || (w.conf.MaxBackups > 0 && i >= w.conf.MaxBackups) {
+			if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+				log.Printf("nlog: fail to remove the rotated log file: %s", err)
+			}
+			dirs[filepath.Dir(backup.path)] = struct{}{}
+			continue
+		}
+		if w.conf.Compress && !strings.HasSuffix(backup.path, compressSuffix) {
+			if err := compressFile(backup.path); err != nil {
+				log.Printf("nlog: fail to compress the rotated log file: %s", err)
+			}
+		}
+	}
+	// removes the month directories which become empty
+	current := filepath.Dir(w.filePath(now))
+	for dir := range dirs {
+		if dir != current {
+			//nolint:errcheck
+			os.Remove(dir)
+		}
+	}
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer src.Close()
+
+	gzPath := path + compressSuffix
+	dst, err := os.OpenFile(gzPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		//nolint:errcheck
+		os.Remove(gzPath)
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func listLogFiles(t *testing.T, logPath string) []string {
+	var files []string
+	err := filepath.Walk(logPath, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(logPath, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(files)
+	return files
+}
+
+func TestRotateWriter(t *testing.T) {
+	a := assert.New(t)
+	logPath := t.TempDir()
+	now := time.Date(2026, 1, 31, 10, 0, 0, 0, time.Local)
+	w := newRotateWriter(logPath, "app.log", &nconf.LogRotationConfig{
+		MaxSize:    1,
+		Daily:      true,
+		MaxBackups: 1,
+		Compress:   true,
+	})
+	w.now = func() time.Time { return now }
+
+	big := strings.Repeat("x", megabyte-1) + "\n"
+	_, err := w.Write([]byte(big))
+	a.Nil(err)
+	// exceeds the max size
+	now = now.Add(time.Minute)
+	_, err = w.Write([]byte("line 2\n"))
+	a.Nil(err)
+	// the day changes
+	now = now.Add(20 * time.Hour)
+	_, err = w.Write([]byte("line 3\n"))
+	a.Nil(err)
+	a.Nil(w.Close())
+	// the rotated file stays in its month directory
+	a.Equal([]string{
+		"202601/app-2026-02-01T06-01-00.000.log.gz",
+		"202602/app.log",
+	}, listLogFiles(t, logPath))
+
+	gzFile, err := os.Open(filepath.Join(logPath, "202601/app-2026-02-01T06-01-00.000.log.gz"))
+	a.Nil(err)
+	defer gzFile.Close()
+	gz, err := gzip.NewReader(gzFile)
+	a.Nil(err)
+	data, err := io.ReadAll(gz)
+	a.Nil(err)
+	a.Equal("line 2\n", string(data))
+
+	// keeps 1 backup, the empty month directory is removed
+	now = now.AddDate(0, 0, 1)
+	_, err = w.Write([]byte("line 4\n"))
+	a.Nil(err)
+	a.Nil(w.Close())
+	a.Equal([]string{
+		"202602/app-2026-02-02T06-01-00.000.log.gz",
+		"202602/app.log",
+	}, listLogFiles(t, logPath))
+	_, err = os.Stat(filepath.Join(logPath, "202601"))
+	a.True(os.IsNotExist(err))
+}
+
+func TestRotateWriterMaxAge(t *testing.T) {
+	a := assert.New(t)
+	logPath := t.TempDir()
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	w := newRotateWriter(logPath, "app.log", &nconf.LogRotationConfig{MaxAge: 48 * time.Hour})
+	w.now = func() time.Time { return now }
+
+	for i := 0; i < 4; i++ {
+		// rotates monthly only
+		_, err := w.Write([]byte("line\n"))
+		a.Nil(err)
+		now = now.AddDate(0, 1, 0)
+	}
+	a.Nil(w.Close())
+	a.Equal([]string{
+		"202605/app-2026-06-01T00-00-00.000.log",
+		"202606/app.log",
+	}, listLogFiles(t, logPath))
+}
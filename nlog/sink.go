@@ -0,0 +1,161 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nf-go/nfgo/nconf"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const netSinkTimeout = 3 * time.Second
+
+// newSinkCores - returns the cores of log.sinks, or the core of the file in log.logPath or stderr if there is no sink.
+// The closers release the async queue, the files and the connections.
+func newSinkCores(config *nconf.Config, zapConfig *zap.Config) ([]zapcore.Core, []io.Closer) {
+	logConf := config.Log
+	var queue *asyncQueue
+	var closers []io.Closer
+	if async := logConf.Async; async != nil && async.Enabled {
+		queue = newAsyncQueue(async)
+		// it is closed before the sinks, so that the queued entries are written
+		closers = append(closers, queue)
+	}
+	newCore := func(enc zapcore.Encoder, ws zapcore.WriteSyncer, level zapcore.LevelEnabler) zapcore.Core {
+		if queue != nil {
+			return newErrorCore(queue.newCore(enc, ws, level), logConf.ErrorStack)
+		}
+		return newErrorCore(zapcore.NewCore(enc, ws, level), logConf.ErrorStack)
+	}
+
+	if len(logConf.Sinks) == 0 {
+		encoder := newEncoder(zapConfig.Encoding, zapConfig.EncoderConfig)
+		if logConf.LogPath == "" {
+			return []zapcore.Core{newCore(encoder, zapcore.Lock(os.Stderr), zapcore.DebugLevel)}, closers
+		}
+		w := newRotateWriter(logConf.LogPath, logFilename(config, logConf.LogFilename), logConf.Rotation)
+		return []zapcore.Core{newCore(encoder, w, zapcore.DebugLevel)}, append(closers, w)
+	}
+
+	cores := make([]zapcore.Core, 0, len(logConf.Sinks))
+	for _, sink := range logConf.Sinks {
+		enc, ws, level, closer := newSink(config, zapConfig, sink)
+		cores = append(cores, newCore(enc, ws, level))
+		if closer != nil {
+			closers = append(closers, closer)
+		}
+	}
+	return cores, closers
+}
+
+func newSink(config *nconf.Config, zapConfig *zap.Config, sink *nconf.LogSinkConfig) (zapcore.Encoder, zapcore.WriteSyncer, zapcore.LevelEnabler, io.Closer) {
+	encoderConfig := zapConfig.EncoderConfig
+	if sink.TimestampFormat != "" {
+		encoderConfig.EncodeTime = timeEncoder(sink.TimestampFormat)
+	}
+	encoding := zapConfig.Encoding
+	if sink.Format != "" {
+		encoding = encodingOf(sink.Format)
+	}
+
+	var ws zapcore.WriteSyncer
+	var closer io.Closer
+	switch sink.Type {
+	case nconf.LogSinkStdout:
+		ws = zapcore.Lock(os.Stdout)
+	case nconf.LogSinkFile:
+		rotation := sink.Rotation
+		if rotation == nil {
+			rotation = config.Log.Rotation
+		}
+		w := newRotateWriter(sink.LogPath, logFilename(config, sink.LogFilename), rotation)
+		ws, closer = w, w
+	case nconf.LogSinkTCP, nconf.LogSinkUDP:
+		w := &netWriter{network: sink.Type, addr: sink.Addr}
+		ws, closer = w, w
+	default:
+		ws = zapcore.Lock(os.Stderr)
+	}
+
+	// the entries are filtered by the level of the logger before the sink level, see levelCore
+	level := zapcore.DebugLevel
+	if sink.Level != "" {
+		level = parseLevel(sink.Level).unWrap()
+	}
+	return newEncoder(encoding, encoderConfig), ws, level, closer
+}
+
+func logFilename(config *nconf.Config, filename string) string {
+	if filename == "" {
+		hostname, _ := os.Hostname()
+		filename = fmt.Sprintf("%s.%s.%s.log", config.App.Name, config.App.Profile, hostname)
+	}
+	return filename
+}
+
+// netWriter - sends every entry to a tcp or udp endpoint, it connects lazily and reconnects after a failure.
+type netWriter struct {
+	network string
+	addr    string
+	mu      sync.Mutex
+	conn    net.Conn
+}
+
+func (w *netWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var err error
+	// retries once with a new connection, the last one may be closed by the peer
+	for i := 0; i < 2; i++ {
+		if w.conn == nil {
+			if w.conn, err = net.DialTimeout(w.network, w.addr, netSinkTimeout); err != nil {
+				return 0, err
+			}
+		}
+		//nolint:errcheck
+		w.conn.SetWriteDeadline(time.Now().Add(netSinkTimeout))
+		var n int
+		if n, err = w.conn.Write(p); err == nil {
+			return n, nil
+		}
+		//nolint:errcheck
+		w.conn.Close()
+		w.conn = nil
+	}
+	return 0, err
+}
+
+func (w *netWriter) Sync() error {
+	return nil
+}
+
+func (w *netWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogSinks(t *testing.T) {
+	a := assert.New(t)
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcpListener.Close()
+	udpConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer udpConn.Close()
+
+	tcpLines := make(chan string, 10)
+	go func() {
+		conn, err := tcpListener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			tcpLines <- scanner.Text()
+		}
+	}()
+
+	logPath := t.TempDir()
+	config := &nconf.Config{
+		App: &nconf.AppConfig{Name: "foo-app", Profile: "dev"},
+		Log: &nconf.LogConfig{
+			Level:  "info",
+			Format: "text",
+			Sinks: []*nconf.LogSinkConfig{
+				{Type: nconf.LogSinkStderr},
+				{Type: nconf.LogSinkFile, Level: "warn", Format: "json", LogPath: logPath, LogFilename: "error.log"},
+				{Type: nconf.LogSinkTCP, Addr: tcpListener.Addr().String()},
+				{Type: nconf.LogSinkUDP, Format: "json", Addr: udpConn.LocalAddr().String()},
+			},
+		},
+	}
+	InitLogger(config)
+	defer InitLogger(&nconf.Config{App: &nconf.AppConfig{}, Log: &nconf.LogConfig{}})
+
+	Debug("debug log")
+	Info("info log")
+	Warn("warn log")
+	Error("error log")
+
+	files, err := filepath.Glob(filepath.Join(logPath, "*", "error.log"))
+	a.Nil(err)
+	a.Len(files, 1)
+	data, err := os.ReadFile(files[0])
+	a.Nil(err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	a.Len(lines, 2)
+	a.Contains(lines[0], `"msg":"warn log"`)
+	a.Contains(lines[1], `"msg":"error log"`)
+
+	for _, msg := range []string{"info log", "warn log", "error log"} {
+		select {
+		case line := <-tcpLines:
+			a.Contains(line, msg)
+		case <-time.After(3 * time.Second):
+			t.Fatalf("%s is not received by the tcp sink", msg)
+		}
+	}
+
+	buf := make([]byte, 64*1024)
+	for _, msg := range []string{"info log", "warn log", "error log"} {
+		//nolint:errcheck
+		udpConn.SetReadDeadline(time.Now().Add(3 * time.Second))
+		n, _, err := udpConn.ReadFrom(buf)
+		a.Nil(err)
+		a.Contains(string(buf[:n]), `"msg":"`+msg+`"`)
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/nf-go/nfgo/ncontext"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SlogHandlerOption -
+type SlogHandlerOption func(*slogHandler)
+
+// SlogLoggerNameOption - the records are logged by Named(name) instead of the root logger.
+func SlogLoggerNameOption(name string) SlogHandlerOption {
+	return func(h *slogHandler) {
+		h.name = name
+	}
+}
+
+// NewSlogHandler - an slog.Handler which logs by the nlog logger, so the records of log/slog share the sinks,
+// the levels and the redaction of nlog, and are enriched with the MDC of the ctx such as traceID.
+// It can be installed as the default of log/slog by log.slogDefault.
+func NewSlogHandler(opt ...SlogHandlerOption) slog.Handler {
+	h := &slogHandler{}
+	for _, o := range opt {
+		o(h)
+	}
+	return h
+}
+
+type slogHandler struct {
+	name string
+	// fields - the attrs and groups of WithAttrs and WithGroup, a group is a zap.Namespace.
+	fields []zap.Field
+}
+
+// logger - the current logger, it is looked up every time since InitLogger replaces it.
+func (h *slogHandler) logger() *nlogger {
+	return levels.logger(h.name, logger)
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if ctx != nil && traceDebugEnabled.Load() {
+		if mdc, _ := ncontext.CurrentMDC(ctx); mdc != nil && ncontext.ROTraceOf(mdc).Debug() {
+			return true
+		}
+	}
+	return h.logger().Desugar().Core().Enabled(zapLevelOf(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	l := withMDC(ctx, h.logger()).(*nlogger)
+	ce := l.Desugar().Check(zapLevelOf(record.Level), record.Message)
+	if ce == nil {
+		return nil
+	}
+	if !record.Time.IsZero() {
+		ce.Time = record.Time
+	}
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		ce.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+		ce.Caller.Function = frame.Function
+	}
+	fields := make([]zap.Field, 0, len(h.fields)+record.NumAttrs())
+	fields = append(fields, h.fields...)
+	record.Attrs(func(attr slog.Attr) bool {
+		if field, ok := slogAttrField(attr); ok {
+			fields = append(fields, field)
+		}
+		return true
+	})
+	ce.Write(fields...)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]zap.Field, 0, len(h.fields)+len(attrs))
+	fields = append(fields, h.fields...)
+	for _, attr := range attrs {
+		if field, ok := slogAttrField(attr); ok {
+			fields = append(fields, field)
+		}
+	}
+	return &slogHandler{name: h.name, fields: fields}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	fields := make([]zap.Field, 0, len(h.fields)+1)
+	fields = append(fields, h.fields...)
+	return &slogHandler{name: h.name, fields: append(fields, zap.Namespace(name))}
+}
+
+// slogAttrField - ok is false if the attr should be ignored or it is removed by the redaction.
+func slogAttrField(attr slog.Attr) (zap.Field, bool) {
+	value := attr.Value.Resolve()
+	if attr.Key == "" && value.Kind() != slog.KindGroup {
+		return zap.Skip(), false
+	}
+	switch value.Kind() {
+	case slog.KindGroup:
+		attrs := value.Group()
+		if len(attrs) == 0 {
+			return zap.Skip(), false
+		}
+		if attr.Key == "" {
+			return zap.Inline(slogGroup(attrs)), true
+		}
+		return zap.Object(attr.Key, slogGroup(attrs)), true
+	case slog.KindBool:
+		return redactedField(attr.Key, value.Bool(), zap.Bool)
+	case slog.KindDuration:
+		return redactedField(attr.Key, value.Duration(), zap.Duration)
+	case slog.KindFloat64:
+		return redactedField(attr.Key, value.Float64(), zap.Float64)
+	case slog.KindInt64:
+		return redactedField(attr.Key, value.Int64(), zap.Int64)
+	case slog.KindString:
+		return redactedField(attr.Key, value.String(), zap.String)
+	case slog.KindTime:
+		return redactedField(attr.Key, value.Time(), zap.Time)
+	case slog.KindUint64:
+		return redactedField(attr.Key, value.Uint64(), zap.Uint64)
+	}
+	return redactedField(attr.Key, value.Any(), zap.Any)
+}
+
+// redactedField - the field of the value, or the field of the redacted value if it is redacted by log.redaction.
+func redactedField[T any](key string, value T, field func(string, T) zap.Field) (zap.Field, bool) {
+	redacted, ok := redactor.Load().RedactField(key, value)
+	if !ok {
+		return zap.Skip(), false
+	}
+	if v, same := redacted.(T); same {
+		return field(key, v), true
+	}
+	return zap.Any(key, redacted), true
+}
+
+// slogGroup - the attrs of an slog group as a zap object.
+type slogGroup []slog.Attr
+
+func (g slogGroup) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, attr := range g {
+		if field, ok := slogAttrField(attr); ok {
+			field.AddTo(enc)
+		}
+	}
+	return nil
+}
+
+func zapLevelOf(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < slog.LevelError:
+		return zapcore.WarnLevel
+	}
+	return zapcore.ErrorLevel
+}
+
+func slogLevelOf(level Level) slog.Level {
+	switch level {
+	case DebugLevel:
+		return slog.LevelDebug
+	case InfoLevel:
+		return slog.LevelInfo
+	case WarnLevel:
+		return slog.LevelWarn
+	case ErrorLevel:
+		return slog.LevelError
+	}
+	return slog.LevelError + 4
+}
+
+// NewSlogLogger - an NLogger which logs by the slog.Handler.
+// Its Fatal logs and exits, its Panic logs and panics, they are logged at ERROR+4.
+func NewSlogLogger(handler slog.Handler) NLogger {
+	return &slogLogger{handler}
+}
+
+type slogLogger struct {
+	handler slog.Handler
+}
+
+func (l *slogLogger) IsLevelEnabled(level Level) bool {
+	return l.handler.Enabled(context.Background(), slogLevelOf(level))
+}
+
+// LevelString - the lowest enabled level.
+func (l *slogLogger) LevelString() string {
+	for _, level := range []Level{DebugLevel, InfoLevel, WarnLevel, ErrorLevel} {
+		if l.IsLevelEnabled(level) {
+			return level.String()
+		}
+	}
+	return FatalLevel.String()
+}
+
+func (l *slogLogger) WithError(err error) NLogger {
+	return l.WithField("error", err)
+}
+
+func (l *slogLogger) WithField(key string, value interface{}) NLogger {
+	return &slogLogger{l.handler.WithAttrs([]slog.Attr{slog.Any(key, value)})}
+}
+
+func (l *slogLogger) WithFields(fields Fields) NLogger {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, key := range keys {
+		attrs = append(attrs, slog.Any(key, fields[key]))
+	}
+	return &slogLogger{l.handler.WithAttrs(attrs)}
+}
+
+func (l *slogLogger) log(level Level, msg func() string) {
+	slogLevel := slogLevelOf(level)
+	ctx := context.Background()
+	if !l.handler.Enabled(ctx, slogLevel) {
+		return
+	}
+	var pcs [1]uintptr
+	// skips runtime.Callers, log and the method of NLogger
+	runtime.Callers(3, pcs[:])
+	//nolint:errcheck
+	l.handler.Handle(ctx, slog.NewRecord(time.Now(), slogLevel, msg(), pcs[0]))
+}
+
+func (l *slogLogger) Debugf(format string, args ...interface{}) {
+	l.log(DebugLevel, func() string { return fmt.Sprintf(format, args...) })
+}
+
+func (l *slogLogger) Infof(format string, args ...interface{}) {
+	l.log(InfoLevel, func() string { return fmt.Sprintf(format, args...) })
+}
+
+func (l *slogLogger) Warnf(format string, args ...interface{}) {
+	l.log(WarnLevel, func() string { return fmt.Sprintf(format, args...) })
+}
+
+func (l *slogLogger) Errorf(format string, args ...interface{}) {
+	l.log(ErrorLevel, func() string { return fmt.Sprintf(format, args...) })
+}
+
+func (l *slogLogger) Fatalf(format string, args ...interface{}) {
+	l.log(FatalLevel, func() string { return fmt.Sprintf(format, args...) })
+	os.Exit(1)
+}
+
+func (l *slogLogger) Panicf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	l.log(PanicLevel, func() string { return msg })
+	panic(msg)
+}
+
+func (l *slogLogger) Debug(args ...interface{}) {
+	l.log(DebugLevel, func() string { return fmt.Sprint(args...) })
+}
+
+func (l *slogLogger) Info(args ...interface{}) {
+	l.log(InfoLevel, func() string { return fmt.Sprint(args...) })
+}
+
+func (l *slogLogger) Warn(args ...interface{}) {
+	l.log(WarnLevel, func() string { return fmt.Sprint(args...) })
+}
+
+func (l *slogLogger) Error(args ...interface{}) {
+	l.log(ErrorLevel, func() string { return fmt.Sprint(args...) })
+}
+
+func (l *slogLogger) Fatal(args ...interface{}) {
+	l.log(FatalLevel, func() string { return fmt.Sprint(args...) })
+	os.Exit(1)
+}
+
+func (l *slogLogger) Panic(args ...interface{}) {
+	msg := fmt.Sprint(args...)
+	l.log(PanicLevel, func() string { return msg })
+	panic(msg)
+}
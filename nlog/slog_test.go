@@ -0,0 +1,89 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogHandler(t *testing.T) {
+	a := assert.New(t)
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+	readLog := initFileLogger(t, &nconf.LogConfig{
+		Level:       "info",
+		CallerPrint: true,
+		SlogDefault: true,
+		Redaction:   []*nconf.LogRedactionRule{{Fields: []string{"password"}}},
+	})
+
+	mdc := ncontext.NewMDC()
+	mdc.SetTraceID("t1")
+	mdc.SetSubjectID("s1")
+	ctx := ncontext.WithMDC(context.Background(), mdc)
+
+	logger := slog.With("lib", "foo").WithGroup("req")
+	logger.DebugContext(ctx, "debug msg")
+	logger.InfoContext(ctx, "info msg", "id", 1, "password", "p1", slog.Group("user", "name", "bar"), "elapsed", time.Second)
+	slog.New(NewSlogHandler(SlogLoggerNameOption("lib"))).Warn("named msg")
+
+	lines := strings.Split(strings.TrimSpace(readLog()), "\n")
+	a.Len(lines, 2)
+	entry := map[string]interface{}{}
+	a.Nil(json.Unmarshal([]byte(lines[0]), &entry))
+	a.Equal("info msg", entry["msg"])
+	a.Equal("t1", entry["traceID"])
+	a.Equal("s1", entry["subjectID"])
+	a.Equal("foo", entry["lib"])
+	a.Contains(entry["caller"], "nlog/slog_test.go")
+	a.Equal(map[string]interface{}{
+		"id":       1.0,
+		"password": "******",
+		"user":     map[string]interface{}{"name": "bar"},
+		"elapsed":  1.0,
+	}, entry["req"])
+	a.Contains(lines[1], `"logger":"lib","caller":"nlog/slog_test.go`)
+}
+
+func TestSlogLogger(t *testing.T) {
+	a := assert.New(t)
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true, Level: slog.LevelInfo}))
+	a.False(logger.IsLevelEnabled(DebugLevel))
+	a.Equal("info", logger.LevelString())
+
+	logger.Debug("debug msg")
+	logger.WithFields(Fields{"k2": 2, "k1": "v1"}).Infof("hello %s", "world")
+	logger.WithField("k3", "v3").Warn("warn msg")
+	a.Panics(func() { logger.Panic("panic msg") })
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	a.Len(lines, 3)
+	a.Contains(lines[0], `"level":"INFO","source":{`)
+	a.Contains(lines[0], "nlog/slog_test.go")
+	a.Contains(lines[0], `"msg":"hello world","k1":"v1","k2":2}`)
+	a.Contains(lines[1], `"level":"WARN"`)
+	a.Contains(lines[2], `"level":"ERROR+4","source"`)
+}
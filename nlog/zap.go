@@ -15,10 +15,8 @@
 package nlog
 
 import (
-	"fmt"
 	"log"
-	"os"
-	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/nf-go/nfgo/nconf"
@@ -41,7 +39,7 @@ func newDefaultZapConfig() *zap.Config {
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
 	return &zap.Config{
-		Level:       zap.NewAtomicLevelAt(zap.InfoLevel),
+		Level:       levels.root,
 		Development: false,
 		Sampling: &zap.SamplingConfig{
 			Initial:    100,
@@ -54,8 +52,8 @@ func newDefaultZapConfig() *zap.Config {
 	}
 }
 
-func mustNewZapLogger(zapConfig *zap.Config) *zap.SugaredLogger {
-	logger, err := zapConfig.Build()
+func mustNewZapLogger(zapConfig *zap.Config, cores ...zapcore.Core) *zap.SugaredLogger {
+	logger, err := newZapLogger(zapConfig, cores...)
 	if err != nil {
 		log.Fatal("fail to new default zap logger: ", err)
 	}
@@ -63,19 +61,107 @@ func mustNewZapLogger(zapConfig *zap.Config) *zap.SugaredLogger {
 	return sugar
 }
 
+// newZapLogger - builds the logger as zapConfig.Build does, but tees the cores if there is any,
+// otherwise writes to zapConfig.OutputPaths. The cores should enable all the levels, the entries are
+// filtered by zapConfig.Level or the level of the named logger, see levelCore.
+func newZapLogger(zapConfig *zap.Config, cores ...zapcore.Core) (*zap.Logger, error) {
+	errOutput, _, err := zap.Open(zapConfig.ErrorOutputPaths...)
+	if err != nil {
+		return nil, err
+	}
+	if len(cores) == 0 {
+		output, _, err := zap.Open(zapConfig.OutputPaths...)
+		if err != nil {
+			return nil, err
+		}
+		cores = append(cores, zapcore.NewCore(newEncoder(zapConfig.Encoding, zapConfig.EncoderConfig), output, zapcore.DebugLevel))
+	}
+
+	tee := zapcore.NewTee(cores...)
+	var core zapcore.Core = &levelCore{tee, nil, zapConfig.Level}
+	if sampling := zapConfig.Sampling; sampling != nil {
+		core = &levelCore{zapcore.NewSamplerWithOptions(tee, time.Second, sampling.Initial, sampling.Thereafter), tee, zapConfig.Level}
+	}
+
+	opts := []zap.Option{zap.ErrorOutput(errOutput)}
+	if !zapConfig.DisableCaller {
+		opts = append(opts, zap.AddCaller())
+	}
+	if !zapConfig.DisableStacktrace {
+		opts = append(opts, zap.AddStacktrace(zap.ErrorLevel))
+	}
+	if len(zapConfig.InitialFields) > 0 {
+		keys := make([]string, 0, len(zapConfig.InitialFields))
+		for key := range zapConfig.InitialFields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fields := make([]zap.Field, 0, len(keys))
+		for _, key := range keys {
+			fields = append(fields, zap.Any(key, zapConfig.InitialFields[key]))
+		}
+		opts = append(opts, zap.Fields(fields...))
+	}
+	return zap.New(core, opts...), nil
+}
+
+func newEncoder(encoding string, encoderConfig zapcore.EncoderConfig) zapcore.Encoder {
+	if encoding == "console" {
+		return zapcore.NewConsoleEncoder(encoderConfig)
+	}
+	return zapcore.NewJSONEncoder(encoderConfig)
+}
+
 func setLevel(zapConfig *zap.Config, logConf *nconf.LogConfig) {
-	level := parseLevel(logConf.Level)
-	zapConfig.Level = zap.NewAtomicLevelAt(level.unWrap())
+	zapConfig.Level = levels.root
+	levels.setRoot(parseLevel(logConf.Level))
+	for name, level := range logConf.Levels {
+		setNamedLevel(name, level)
+	}
+}
+
+func setSampling(zapConfig *zap.Config, logConf *nconf.LogConfig) {
+	sampling := logConf.Sampling
+	if sampling == nil {
+		return
+	}
+	if sampling.Disabled {
+		zapConfig.Sampling = nil
+		return
+	}
+	if sampling.Initial > 0 {
+		zapConfig.Sampling.Initial = sampling.Initial
+	}
+	if sampling.Thereafter > 0 {
+		zapConfig.Sampling.Thereafter = sampling.Thereafter
+	}
+}
+
+// setNamedLevel - an empty level makes the named logger follow the root level.
+func setNamedLevel(name string, level string) {
+	if level == "" {
+		ResetNamedLevel(name)
+		return
+	}
+	SetNamedLevel(name, parseLevel(level))
 }
 
 func setFormatter(zapConfig *zap.Config, logConf *nconf.LogConfig) {
-	var layout string
-	if logConf.TimestampFormat == "" {
+	zapConfig.EncoderConfig.EncodeTime = timeEncoder(logConf.TimestampFormat)
+	if logConf.Format != "" {
+		zapConfig.Encoding = encodingOf(logConf.Format)
+	}
+
+	if logConf.CallerPrint {
+		zapConfig.EncoderConfig.CallerKey = "caller"
+	}
+}
+
+func timeEncoder(layout string) zapcore.TimeEncoder {
+	if layout == "" {
 		layout = "2006-01-02T15:04:05.000Z07:00"
-	} else {
-		layout = logConf.TimestampFormat
 	}
-	zapConfig.EncoderConfig.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+	return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
 		type appendTimeEncoder interface {
 			AppendTimeLayout(time.Time, string)
 		}
@@ -85,33 +171,12 @@ func setFormatter(zapConfig *zap.Config, logConf *nconf.LogConfig) {
 		}
 		enc.AppendString(t.Format(layout))
 	}
-	switch logConf.Format {
-	case "text":
-		zapConfig.Encoding = "console"
-	case "json":
-		zapConfig.Encoding = "json"
-	}
-
-	if logConf.CallerPrint {
-		zapConfig.EncoderConfig.CallerKey = "caller"
-	}
 }
 
-func setOutput(zapConfig *zap.Config, config *nconf.Config) {
-	logConf := config.Log
-	if logConf.LogPath == "" {
-		return
-	}
-	logFilename := logConf.LogFilename
-	if logFilename == "" {
-		hostname, _ := os.Hostname()
-		logFilename = fmt.Sprintf("%s.%s.%s.log", config.App.Name, config.App.Profile, hostname)
+// encodingOf - the log format => the zap encoding.
+func encodingOf(format string) string {
+	if format == "text" {
+		return "console"
 	}
-	logPath := filepath.Join(logConf.LogPath, time.Now().Format("200601"))
-	if err := os.MkdirAll(logPath, 0755); err != nil {
-		log.Fatal("can't create log dir: ", err)
-	}
-	fullFilename := filepath.Join(logPath, logFilename)
-
-	zapConfig.OutputPaths = []string{fullFilename}
+	return "json"
 }
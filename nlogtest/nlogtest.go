@@ -0,0 +1,210 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nlogtest captures the logs of nlog in memory, so the tests can assert what is logged.
+//
+// The loggers of nlog are global, so the tests capturing the logs must not run in parallel.
+package nlogtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/nlog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var (
+	mu      sync.Mutex
+	current *Logs
+)
+
+// Entry - a captured entry, the fields include the ones of the MDC such as traceID.
+type Entry struct {
+	Time       time.Time
+	Level      nlog.Level
+	LoggerName string
+	Message    string
+	Fields     map[string]interface{}
+}
+
+// Logs - the captured entries.
+type Logs struct {
+	observed *observer.ObservedLogs
+}
+
+// CaptureOption -
+type CaptureOption func(*captureOptions)
+
+type captureOptions struct {
+	level *nlog.Level
+}
+
+// LevelOption - sets the root level while capturing, it is restored afterwards.
+func LevelOption(level nlog.Level) CaptureOption {
+	return func(opts *captureOptions) {
+		opts.level = &level
+	}
+}
+
+// Capture - captures the logs of nlog until the test and its subtests finish, then restores the previous loggers.
+// The entries are filtered by the levels of the loggers as before.
+func Capture(t testing.TB, opt ...CaptureOption) *Logs {
+	t.Helper()
+	opts := &captureOptions{}
+	for _, o := range opt {
+		o(opts)
+	}
+
+	core, observed := observer.New(zapcore.DebugLevel)
+	logs := &Logs{observed: observed}
+	restore := nlog.ReplaceCore(core)
+	if opts.level != nil {
+		lastLevel := currentLevel()
+		nlog.SetLevel(*opts.level)
+		lastRestore := restore
+		restore = func() {
+			nlog.SetLevel(lastLevel)
+			lastRestore()
+		}
+	}
+
+	mu.Lock()
+	last := current
+	current = logs
+	mu.Unlock()
+	t.Cleanup(func() {
+		restore()
+		mu.Lock()
+		current = last
+		mu.Unlock()
+	})
+	return logs
+}
+
+// currentLevel - the root level of nlog.
+func currentLevel() nlog.Level {
+	for _, level := range []nlog.Level{nlog.DebugLevel, nlog.InfoLevel, nlog.WarnLevel, nlog.ErrorLevel, nlog.PanicLevel} {
+		if nlog.IsLevelEnabled(level) {
+			return level
+		}
+	}
+	return nlog.FatalLevel
+}
+
+// All - all the captured entries.
+func (l *Logs) All() []Entry {
+	observed := l.observed.All()
+	entries := make([]Entry, 0, len(observed))
+	for _, e := range observed {
+		entries = append(entries, Entry{
+			Time:       e.Time,
+			Level:      nlog.Level(e.Level),
+			LoggerName: e.LoggerName,
+			Message:    e.Message,
+			Fields:     e.ContextMap(),
+		})
+	}
+	return entries
+}
+
+// Filter - the entries at the level whose messages contain msgContains and whose fields contain the fields.
+func (l *Logs) Filter(level nlog.Level, msgContains string, fields nlog.Fields) []Entry {
+	var entries []Entry
+	for _, e := range l.All() {
+		if e.Level == level && strings.Contains(e.Message, msgContains) && containsFields(e.Fields, fields) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Len - the number of the captured entries.
+func (l *Logs) Len() int {
+	return l.observed.Len()
+}
+
+// Reset - discards the captured entries.
+func (l *Logs) Reset() {
+	l.observed.TakeAll()
+}
+
+// AssertLogged - asserts that an entry matching the level, msgContains and the fields is captured
+// by the current Capture, see Logs.Filter.
+func AssertLogged(t testing.TB, level nlog.Level, msgContains string, fields nlog.Fields) bool {
+	t.Helper()
+	logs := currentLogs(t)
+	if len(logs.Filter(level, msgContains, fields)) > 0 {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("no %s entry containing %q with the fields %v is logged", level, msgContains, fields),
+		"the logged entries:\n%s", logs)
+}
+
+// AssertNotLogged - the opposite of AssertLogged.
+func AssertNotLogged(t testing.TB, level nlog.Level, msgContains string, fields nlog.Fields) bool {
+	t.Helper()
+	entries := currentLogs(t).Filter(level, msgContains, fields)
+	if len(entries) == 0 {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("the %s entry containing %q with the fields %v is logged", level, msgContains, fields),
+		"the matched entries:\n%s", formatEntries(entries))
+}
+
+func currentLogs(t testing.TB) *Logs {
+	t.Helper()
+	mu.Lock()
+	defer mu.Unlock()
+	if current == nil {
+		t.Fatal("nlogtest.Capture is not called")
+	}
+	return current
+}
+
+// String - the captured entries one per line.
+func (l *Logs) String() string {
+	return formatEntries(l.All())
+}
+
+func formatEntries(entries []Entry) string {
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&b, "%s\t%s\t%s\t%v\n", e.Level, e.LoggerName, e.Message, e.Fields)
+	}
+	return b.String()
+}
+
+// containsFields - the values are compared after type conversion, such as int and int64, an error is
+// compared by its message.
+func containsFields(actual map[string]interface{}, expected nlog.Fields) bool {
+	for key, value := range expected {
+		v, ok := actual[key]
+		if !ok {
+			return false
+		}
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		if !assert.ObjectsAreEqualValues(value, v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nlogtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nlog"
+	"github.com/stretchr/testify/assert"
+)
+
+func handle(ctx context.Context) {
+	nlog.Logger(ctx).WithField("orderID", 1001).Debug("handling")
+	nlog.Logger(ctx).WithError(errors.New("db is down")).Error("fail to handle")
+	nlog.Named("ndb").Warn("slow sql")
+}
+
+func TestCapture(t *testing.T) {
+	a := assert.New(t)
+	mdc := ncontext.NewMDC()
+	mdc.SetTraceID("t1")
+	ctx := ncontext.WithMDC(context.Background(), mdc)
+
+	t.Run("info", func(t *testing.T) {
+		logs := Capture(t)
+		handle(ctx)
+		AssertLogged(t, nlog.ErrorLevel, "fail to", nlog.Fields{"traceID": "t1", "error": errors.New("db is down")})
+		AssertLogged(t, nlog.WarnLevel, "slow", nil)
+		AssertNotLogged(t, nlog.DebugLevel, "", nil)
+		a.Equal(2, logs.Len())
+		a.Equal("ndb", logs.All()[1].LoggerName)
+	})
+
+	t.Run("debug", func(t *testing.T) {
+		logs := Capture(t, LevelOption(nlog.DebugLevel))
+		handle(ctx)
+		AssertLogged(t, nlog.DebugLevel, "handling", nlog.Fields{"traceID": "t1", "orderID": 1001})
+		a.Len(logs.Filter(nlog.DebugLevel, "", nlog.Fields{"orderID": 1002}), 0)
+		logs.Reset()
+		a.Equal(0, logs.Len())
+
+		mockT := &testing.T{}
+		a.False(AssertLogged(mockT, nlog.InfoLevel, "", nil))
+		a.True(mockT.Failed())
+	})
+
+	a.False(nlog.IsLevelEnabled(nlog.DebugLevel))
+	nlog.Info("not captured")
+}
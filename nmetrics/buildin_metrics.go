@@ -16,11 +16,19 @@ package nmetrics
 
 import (
 	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/nlog"
+	"github.com/nf-go/nfgo/npool"
 	"github.com/nf-go/nfgo/nutil/ntypes"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
 func (s *server) regitserBuildinCollector(config *nconf.Config) error {
+	if err := s.registry.Register(nlog.DroppedEntriesCollector()); err != nil {
+		return err
+	}
+	if err := s.registry.Register(npool.Collector()); err != nil {
+		return err
+	}
 	conf := config.Metrics
 	if ntypes.BoolValue(conf.BuildInfoCollector) {
 		if err := s.registry.Register(collectors.NewBuildInfoCollector()); err != nil {
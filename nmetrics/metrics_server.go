@@ -69,6 +69,9 @@ func NewServer(config *nconf.Config, opt ...ServerOption) (Server, error) {
 
 	serverMux := http.NewServeMux()
 	serverMux.Handle(metricsConfig.MetricsPath, promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{}))
+	if opts.logLevelPath != "" {
+		serverMux.Handle(opts.logLevelPath, nlog.LevelHandler())
+	}
 	s.httpServer = &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", metricsConfig.Host, metricsConfig.Port),
 		Handler: serverMux,
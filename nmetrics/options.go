@@ -17,7 +17,8 @@ package nmetrics
 import "gorm.io/gorm"
 
 type serverOptions struct {
-	db *gorm.DB
+	db           *gorm.DB
+	logLevelPath string
 }
 
 // ServerOption -
@@ -29,3 +30,10 @@ func DBOption(db *gorm.DB) ServerOption {
 		opts.db = db
 	}
 }
+
+// LogLevelOption - mounts nlog.LevelHandler on the path of the metrics server, such as /loglevel.
+func LogLevelOption(path string) ServerOption {
+	return func(opts *serverOptions) {
+		opts.logLevelPath = path
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npool
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pools - the running pools by their names
+var pools sync.Map
+
+var rejectedTasks = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "nfgo",
+	Subsystem: "pool",
+	Name:      "rejected_tasks_total",
+	Help:      "The number of the tasks rejected since the queue of the pool is full.",
+}, []string{"pool"})
+
+var (
+	queueDepthDesc = prometheus.NewDesc("nfgo_pool_queue_depth",
+		"The number of the tasks queued in the pool.", []string{"pool"}, nil)
+	busyWorkersDesc = prometheus.NewDesc("nfgo_pool_busy_workers",
+		"The number of the workers of the pool which are running the tasks.", []string{"pool"}, nil)
+)
+
+// Collector - the queue depth, the busy workers and the rejected tasks of the running pools, labeled by pool.
+// It is registered by the nmetrics server.
+func Collector() prometheus.Collector {
+	return collector{}
+}
+
+type collector struct{}
+
+func (collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueDepthDesc
+	ch <- busyWorkersDesc
+	rejectedTasks.Describe(ch)
+}
+
+func (collector) Collect(ch chan<- prometheus.Metric) {
+	pools.Range(func(_, v interface{}) bool {
+		p := v.(*pool)
+		ch <- prometheus.MustNewConstMetric(queueDepthDesc, prometheus.GaugeValue, float64(p.QueueDepth()), p.name)
+		ch <- prometheus.MustNewConstMetric(busyWorkersDesc, prometheus.GaugeValue, float64(p.busy.Load()), p.name)
+		return true
+	})
+	rejectedTasks.Collect(ch)
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npool
+
+import (
+	"context"
+	"errors"
+	"log"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nf-go/nfgo/ncontext"
+)
+
+const defaultQueueSize = 1024
+
+// ErrClosed - the task is submitted after the pool is shut down.
+var ErrClosed = errors.New("npool: the pool is shut down")
+
+// Task - the ctx is never canceled and has a copy of the MDC of the context which the task is submitted with,
+// see ncontext.Go.
+type Task func(ctx context.Context)
+
+// Pool - a bounded pool of the workers which run the submitted tasks. The tasks wait in the queue while all the
+// workers are busy, and Submit blocks while the queue is full, so the submitters are slowed down to the pace
+// of the workers. The depth of the queue is reported to nmetrics, see Collector.
+type Pool interface {
+	// Submit - queues the task, it blocks until the queue has room, ctx is done or the pool is shut down.
+	Submit(ctx context.Context, task Task) error
+	// TrySubmit - queues the task only if the queue has room, it reports whether the task is queued.
+	TrySubmit(ctx context.Context, task Task) bool
+	// QueueDepth - the number of the queued tasks.
+	QueueDepth() int
+	// Shutdown - stops accepting the tasks and waits until the queued ones are done or ctx is done.
+	Shutdown(ctx context.Context) error
+}
+
+type poolOptions struct {
+	workers   int
+	queueSize int
+}
+
+// PoolOption -
+type PoolOption func(*poolOptions)
+
+// WorkersOption - the number of the workers, it defaults to GOMAXPROCS.
+func WorkersOption(n int) PoolOption {
+	return func(opts *poolOptions) {
+		opts.workers = n
+	}
+}
+
+// QueueSizeOption - the max number of the queued tasks, it defaults to 1024.
+func QueueSizeOption(n int) PoolOption {
+	return func(opts *poolOptions) {
+		opts.queueSize = n
+	}
+}
+
+// NewPool - starts the workers of the pool, the name labels the metrics of the pool, so it must be unique.
+func NewPool(name string, opt ...PoolOption) (Pool, error) {
+	if name == "" {
+		return nil, errors.New("npool: the name of the pool is required")
+	}
+	opts := &poolOptions{}
+	for _, o := range opt {
+		o(opts)
+	}
+	if opts.workers <= 0 {
+		opts.workers = runtime.GOMAXPROCS(0)
+	}
+	if opts.queueSize <= 0 {
+		opts.queueSize = defaultQueueSize
+	}
+
+	p := &pool{
+		name:    name,
+		tasks:   make(chan queuedTask, opts.queueSize),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if _, loaded := pools.LoadOrStore(name, p); loaded {
+		return nil, errors.New("npool: the pool " + name + " already exists")
+	}
+	p.wg.Add(opts.workers)
+	for i := 0; i < opts.workers; i++ {
+		go p.work()
+	}
+	go func() {
+		p.wg.Wait()
+		close(p.done)
+	}()
+	return p, nil
+}
+
+// MustNewPool -
+func MustNewPool(name string, opt ...PoolOption) Pool {
+	p, err := NewPool(name, opt...)
+	if err != nil {
+		log.Fatal("fail to create the pool: ", err)
+	}
+	return p
+}
+
+type queuedTask struct {
+	ctx  context.Context
+	task Task
+}
+
+type pool struct {
+	name  string
+	tasks chan queuedTask
+	busy  atomic.Int64
+	wg    sync.WaitGroup
+	// closing - it is closed by Shutdown, the blocked submitters return ErrClosed
+	closing chan struct{}
+	// done - it is closed once all the workers exit
+	done chan struct{}
+	// senders - the submitters which may send to the queue, the queue is closed after they are all done
+	senders sync.WaitGroup
+	// mu - guards closed and the registration of the senders, it is never held while sending
+	mu     sync.RWMutex
+	closed bool
+}
+
+// acquire - registers the submitter, false if the pool is shut down.
+func (p *pool) acquire() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return false
+	}
+	p.senders.Add(1)
+	return true
+}
+
+func (p *pool) Submit(ctx context.Context, task Task) error {
+	if !p.acquire() {
+		return ErrClosed
+	}
+	defer p.senders.Done()
+	select {
+	case p.tasks <- queuedTask{ctx: ncontext.Background(ctx), task: task}:
+		return nil
+	case <-p.closing:
+		return ErrClosed
+	case <-ctx.Done():
+		rejectedTasks.WithLabelValues(p.name).Inc()
+		if err := ncontext.CheckDeadline(ctx); err != nil {
+			return err
+		}
+		return ctx.Err()
+	}
+}
+
+func (p *pool) TrySubmit(ctx context.Context, task Task) bool {
+	if !p.acquire() {
+		return false
+	}
+	defer p.senders.Done()
+	select {
+	case p.tasks <- queuedTask{ctx: ncontext.Background(ctx), task: task}:
+		return true
+	default:
+		rejectedTasks.WithLabelValues(p.name).Inc()
+		return false
+	}
+}
+
+func (p *pool) QueueDepth() int {
+	return len(p.tasks)
+}
+
+func (p *pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.closing)
+		pools.Delete(p.name)
+		rejectedTasks.DeleteLabelValues(p.name)
+		// the queue is closed once no one can send to it, the workers exit after the queued tasks are done
+		go func() {
+			p.senders.Wait()
+			close(p.tasks)
+		}()
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *pool) work() {
+	defer p.wg.Done()
+	for t := range p.tasks {
+		p.run(t)
+	}
+}
+
+func (p *pool) run(t queuedTask) {
+	p.busy.Add(1)
+	defer p.busy.Add(-1)
+	defer ncontext.Recover(t.ctx)
+	t.task(t.ctx)
+}
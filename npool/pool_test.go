@@ -0,0 +1,108 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npool
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	a := assert.New(t)
+	p, err := NewPool("test", WorkersOption(1), QueueSizeOption(1))
+	a.Nil(err)
+	_, err = NewPool("test")
+	a.NotNil(err)
+
+	mdc := ncontext.NewMDC()
+	mdc.SetTraceID("t1")
+	ctx, cancel := context.WithCancel(ncontext.WithMDC(context.Background(), mdc))
+	release := make(chan struct{})
+	started := make(chan string, 1)
+	a.Nil(p.Submit(ctx, func(ctx context.Context) {
+		m, _ := ncontext.CurrentMDC(ctx)
+		started <- m.TraceID()
+		<-release
+	}))
+	cancel()
+	a.Equal("t1", <-started)
+
+	var ran atomic.Int32
+	task := func(ctx context.Context) {
+		a.Nil(ctx.Err())
+		ran.Add(1)
+	}
+	a.True(p.TrySubmit(context.Background(), task))
+	a.Equal(1, p.QueueDepth())
+	a.False(p.TrySubmit(context.Background(), task))
+	a.Equal(float64(1), testutil.ToFloat64(rejectedTasks.WithLabelValues("test")))
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	a.Equal(nerrors.ErrDeadlineExceeded, p.Submit(timeoutCtx, task))
+
+	expected := `
+# HELP nfgo_pool_queue_depth The number of the tasks queued in the pool.
+# TYPE nfgo_pool_queue_depth gauge
+nfgo_pool_queue_depth{pool="test"} 1
+`
+	a.Nil(testutil.CollectAndCompare(Collector(), strings.NewReader(expected), "nfgo_pool_queue_depth"))
+
+	close(release)
+	a.Nil(p.Submit(context.Background(), func(ctx context.Context) {
+		panic("crash")
+	}))
+	a.Nil(p.Shutdown(context.Background()))
+	a.Equal(int32(1), ran.Load())
+	a.Equal(ErrClosed, p.Submit(context.Background(), task))
+	a.False(p.TrySubmit(context.Background(), task))
+	a.Equal(0, testutil.CollectAndCount(Collector(), "nfgo_pool_queue_depth"))
+}
+
+func TestPoolShutdownBlockedSubmit(t *testing.T) {
+	a := assert.New(t)
+	p := MustNewPool("blocked", WorkersOption(1), QueueSizeOption(1))
+	release := make(chan struct{})
+	started := make(chan struct{})
+	a.Nil(p.Submit(context.Background(), func(ctx context.Context) {
+		close(started)
+		<-release
+	}))
+	<-started
+	a.Nil(p.Submit(context.Background(), func(ctx context.Context) {}))
+
+	submitted := make(chan error)
+	go func() {
+		submitted <- p.Submit(context.Background(), func(ctx context.Context) {})
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	a.Equal(context.DeadlineExceeded, p.Shutdown(timeoutCtx))
+	a.Equal(ErrClosed, <-submitted)
+
+	close(release)
+	a.Nil(p.Shutdown(context.Background()))
+	a.Equal(0, p.QueueDepth())
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntrace
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nutil/nconst"
+)
+
+// defaultBaggageMaxBytes - the limit of W3C Baggage
+const defaultBaggageMaxBytes = 8192
+
+// injectBaggage - key1=value1,key2=value2, the values are percent encoded.
+// The entries are added in the order of the keys until the header exceeds the max bytes.
+func injectBaggage(conf *propagationConfig, mdc ncontext.ROMDC, set func(key string, value string)) {
+	var b strings.Builder
+	for _, key := range conf.baggageKeys {
+		value := mdc.Other(key)
+		if value == nil {
+			continue
+		}
+		entry := key + "=" + url.PathEscape(fmt.Sprint(value))
+		if b.Len() > 0 {
+			entry = "," + entry
+		}
+		if b.Len()+len(entry) > conf.baggageMaxBytes {
+			continue
+		}
+		b.WriteString(entry)
+	}
+	if b.Len() > 0 {
+		set(nconst.HeaderBaggage, b.String())
+	}
+}
+
+// extractBaggage - the entries out of the keys are ignored, so are the properties of the entries
+// and the header beyond the max bytes.
+func extractBaggage(conf *propagationConfig, mdc ncontext.MDC, header func(key string) string) {
+	if len(conf.baggageKeys) == 0 {
+		return
+	}
+	baggage := header(nconst.HeaderBaggage)
+	if baggage == "" || len(baggage) > conf.baggageMaxBytes {
+		return
+	}
+	for _, entry := range strings.Split(baggage, ",") {
+		entry, _, _ = strings.Cut(entry, ";")
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if !isBaggageKey(conf, key) {
+			continue
+		}
+		if value, err := url.PathUnescape(strings.TrimSpace(value)); err == nil {
+			mdc.SetOther(key, value)
+		}
+	}
+}
+
+func isBaggageKey(conf *propagationConfig, key string) bool {
+	for _, k := range conf.baggageKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
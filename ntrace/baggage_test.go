@@ -0,0 +1,66 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntrace
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nutil/nconst"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaggage(t *testing.T) {
+	a := assert.New(t)
+	InitPropagation(&nconf.Config{Trace: &nconf.TraceConfig{
+		Baggage: &nconf.TraceBaggageConfig{Keys: []string{"tenantID", "origin", "note", "orderID"}, MaxBytes: 60},
+	}})
+	defer InitPropagation(&nconf.Config{})
+
+	mdc := ncontext.NewMDC()
+	mdc.SetTraceID("t1")
+	mdc.SetOther("tenantID", "tn1")
+	mdc.SetOther("origin", "app, ios;v=1")
+	mdc.SetOther("note", "too long to be sent in the baggage header")
+	mdc.SetOther("orderID", 1001)
+	mdc.SetOther("secret", "s1")
+	header := injectHeader(mdc)
+	a.Equal("tenantID=tn1,origin=app%2C%20ios%3Bv=1,orderID=1001", header.Get(nconst.HeaderBaggage))
+
+	InitPropagation(&nconf.Config{Trace: &nconf.TraceConfig{
+		Baggage: &nconf.TraceBaggageConfig{Keys: []string{"tenantID", "origin", "note", "orderID"}, MaxBytes: 100},
+	}})
+	header.Set(nconst.HeaderBaggage, header.Get(nconst.HeaderBaggage)+",secret=s2, note = n1;prop=1")
+	mdc = ncontext.NewMDC()
+	a.Nil(Extract(mdc, header.Get))
+	a.Equal("tn1", mdc.Other("tenantID"))
+	a.Equal("app, ios;v=1", mdc.Other("origin"))
+	a.Equal("1001", mdc.Other("orderID"))
+	a.Equal("n1", mdc.Other("note"))
+	a.Nil(mdc.Other("secret"))
+
+	// the header beyond the max bytes is ignored
+	header.Set(nconst.HeaderBaggage, "tenantID=tn1,note="+strings.Repeat("n", 100))
+	mdc = ncontext.NewMDC()
+	a.Nil(Extract(mdc, header.Get))
+	a.Nil(mdc.Other("tenantID"))
+
+	// nothing is propagated without the keys
+	InitPropagation(&nconf.Config{})
+	mdc.SetOther("tenantID", "tn1")
+	a.Empty(injectHeader(mdc).Get(nconst.HeaderBaggage))
+}
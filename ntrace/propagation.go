@@ -0,0 +1,274 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntrace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nutil/nconst"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// invalidTraceID - the ids of all zeros are invalid
+const invalidTraceID = "00000000000000000000000000000000"
+
+var propagation atomic.Pointer[propagationConfig]
+
+func init() {
+	propagation.Store(newPropagationConfig(nil))
+}
+
+// propagationConfig - trace.propagators and trace.baggage
+type propagationConfig struct {
+	propagators     []string
+	baggageKeys     []string
+	baggageMaxBytes int
+}
+
+func newPropagationConfig(traceConf *nconf.TraceConfig) *propagationConfig {
+	c := &propagationConfig{
+		propagators:     []string{nconf.TracePropagatorW3C},
+		baggageMaxBytes: defaultBaggageMaxBytes,
+	}
+	if traceConf == nil {
+		return c
+	}
+	if len(traceConf.Propagators) > 0 {
+		c.propagators = traceConf.Propagators
+	}
+	if baggage := traceConf.Baggage; baggage != nil {
+		c.baggageKeys = baggage.Keys
+		if baggage.MaxBytes > 0 {
+			c.baggageMaxBytes = baggage.MaxBytes
+		}
+	}
+	return c
+}
+
+// InitPropagation - applies trace.propagators and trace.baggage, it is called by the web and rpc servers and the rpc clients.
+func InitPropagation(config *nconf.Config) {
+	propagation.Store(newPropagationConfig(config.Trace))
+}
+
+// NewTraceID - a random trace id of W3C Trace Context, 32 lowercase hex digits.
+func NewTraceID() (string, error) {
+	return randomHex(16)
+}
+
+// NewSpanID - a random span id of W3C Trace Context, 16 lowercase hex digits.
+func NewSpanID() (string, error) {
+	return randomHex(8)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Extract - binds the trace in the headers of the incoming request to mdc, header returns the value of a header.
+//
+// The headers of trace.propagators are tried in order, then X-Trace-ID, and a new sampled trace is started
+// if there is none. The UUID of X-Trace-ID is kept as the trace id if it is the trace of the propagators. The span of the caller becomes the parent span, and a new span is generated for the current service.
+// The entries of the baggage header in trace.baggage.keys are set to the MDC others as strings.
+func Extract(mdc ncontext.MDC, header func(key string) string) error {
+	conf := propagation.Load()
+	extractBaggage(conf, mdc, header)
+	traced := false
+	for _, propagator := range conf.propagators {
+		switch propagator {
+		case nconf.TracePropagatorW3C:
+			traced = extractW3C(mdc, header)
+		case nconf.TracePropagatorB3:
+			traced = extractB3(mdc, header)
+		}
+		if traced {
+			break
+		}
+	}
+	if traced {
+		keepTraceID(mdc, header(nconst.HeaderTraceID))
+	} else {
+		traceID := header(nconst.HeaderTraceID)
+		if traceID == "" {
+			var err error
+			if traceID, err = NewTraceID(); err != nil {
+				return err
+			}
+		}
+		mdc.SetTraceID(traceID)
+		ncontext.TraceOf(mdc).SetSampled(true)
+	}
+
+	spanID, err := NewSpanID()
+	if err != nil {
+		return err
+	}
+	ncontext.TraceOf(mdc).SetSpanID(spanID)
+	return nil
+}
+
+// keepTraceID - the UUID of X-Trace-ID replaces the trace id of traceparent or b3 if they are the same trace,
+// so that the logs of the caller and the callee carry the same trace id, see w3cTraceID.
+func keepTraceID(mdc ncontext.MDC, traceID string) {
+	if w3cID, ok := w3cTraceID(traceID); ok && traceID != w3cID && w3cID == mdc.TraceID() {
+		mdc.SetTraceID(traceID)
+	}
+}
+
+// Inject - sets the headers of the outgoing request by the trace in mdc, set sets a header.
+// X-Trace-ID is always set, traceparent is set only if the trace id is of W3C Trace Context or a UUID.
+// The MDC others in trace.baggage.keys are set to the baggage header.
+func Inject(mdc ncontext.ROMDC, set func(key string, value string)) {
+	tmdc := ncontext.ROTraceOf(mdc)
+	inject(mdc, tmdc.SpanID(), tmdc.Sampled(), tmdc.TraceState(), set)
+}
+
+// InjectContext - Inject by the MDC in ctx, the span in ctx of the same trace, such as the span of the rpc client,
+// is sent as the parent instead of the span of the MDC.
+func InjectContext(ctx context.Context, set func(key string, value string)) {
+	mdc, err := ncontext.CurrentMDC(ctx)
+	if err != nil {
+		return
+	}
+	sc := trace.SpanContextFromContext(ctx)
+	if traceID, _ := w3cTraceID(mdc.TraceID()); !sc.IsValid() || sc.TraceID().String() != traceID {
+		Inject(mdc, set)
+		return
+	}
+	inject(mdc, sc.SpanID().String(), sc.IsSampled(), sc.TraceState().String(), set)
+}
+
+func inject(mdc ncontext.ROMDC, spanID string, sampled bool, traceState string, set func(key string, value string)) {
+	conf := propagation.Load()
+	injectBaggage(conf, mdc, set)
+	if mdc.TraceID() == "" {
+		return
+	}
+	set(nconst.HeaderTraceID, mdc.TraceID())
+	traceID, ok := w3cTraceID(mdc.TraceID())
+	if !ok || !isValidID(spanID, 16) {
+		return
+	}
+	for _, propagator := range conf.propagators {
+		switch propagator {
+		case nconf.TracePropagatorW3C:
+			flags := "00"
+			if sampled {
+				flags = "01"
+			}
+			set(nconst.HeaderTraceparent, "00-"+traceID+"-"+spanID+"-"+flags)
+			if traceState != "" {
+				set(nconst.HeaderTracestate, traceState)
+			}
+		case nconf.TracePropagatorB3:
+			set(nconst.HeaderB3TraceID, traceID)
+			set(nconst.HeaderB3SpanID, spanID)
+			b3Sampled := "0"
+			if sampled {
+				b3Sampled = "1"
+			}
+			set(nconst.HeaderB3Sampled, b3Sampled)
+		}
+	}
+}
+
+// extractW3C - version-traceID-parentID-flags, the headers of the later versions may have more fields.
+func extractW3C(mdc ncontext.MDC, header func(key string) string) bool {
+	traceparent := strings.TrimSpace(header(nconst.HeaderTraceparent))
+	if len(traceparent) < 55 || (len(traceparent) > 55 && traceparent[55] != '-') {
+		return false
+	}
+	version, traceID, parentID, flags := traceparent[0:2], traceparent[3:35], traceparent[36:52], traceparent[53:55]
+	if traceparent[2] != '-' || traceparent[35] != '-' || traceparent[52] != '-' ||
+		!isHex(version) || version == "ff" || (version == "00" && len(traceparent) != 55) ||
+		!isValidID(traceID, 32) || !isValidID(parentID, 16) || !isHex(flags) {
+		return false
+	}
+	flag, _ := strconv.ParseUint(flags, 16, 8)
+	mdc.SetTraceID(traceID)
+	tmdc := ncontext.TraceOf(mdc)
+	tmdc.SetParentSpanID(parentID)
+	tmdc.SetSampled(flag&1 == 1)
+	tmdc.SetTraceState(header(nconst.HeaderTracestate))
+	return true
+}
+
+// extractB3 - the single b3 header is tried first, then the X-B3-* headers.
+func extractB3(mdc ncontext.MDC, header func(key string) string) bool {
+	var traceID, spanID, sampled string
+	if b3 := strings.TrimSpace(header(nconst.HeaderB3)); b3 != "" {
+		// traceID-spanID-sampled-parentSpanID, the last two are optional
+		parts := strings.Split(b3, "-")
+		if len(parts) < 2 || len(parts) > 4 {
+			return false
+		}
+		traceID, spanID = parts[0], parts[1]
+		if len(parts) > 2 {
+			sampled = parts[2]
+		}
+	} else {
+		traceID, spanID = header(nconst.HeaderB3TraceID), header(nconst.HeaderB3SpanID)
+		sampled = header(nconst.HeaderB3Sampled)
+		if header(nconst.HeaderB3Flags) == "1" {
+			sampled = "d"
+		}
+	}
+	// the trace id of 64 bits is left padded
+	if len(traceID) == 16 {
+		traceID = invalidTraceID[:16] + traceID
+	}
+	traceID, spanID = strings.ToLower(traceID), strings.ToLower(spanID)
+	if !isValidID(traceID, 32) || !isValidID(spanID, 16) {
+		return false
+	}
+	mdc.SetTraceID(traceID)
+	tmdc := ncontext.TraceOf(mdc)
+	tmdc.SetParentSpanID(spanID)
+	// the trace is sampled unless the caller says no
+	tmdc.SetSampled(sampled != "0" && sampled != "false")
+	return true
+}
+
+// w3cTraceID - the trace id of W3C Trace Context, or the UUID without the hyphens.
+func w3cTraceID(traceID string) (string, bool) {
+	if len(traceID) == 36 {
+		traceID = strings.ToLower(strings.ReplaceAll(traceID, "-", ""))
+	}
+	return traceID, isValidID(traceID, 32)
+}
+
+func isValidID(id string, size int) bool {
+	return len(id) == size && isHex(id) && id != invalidTraceID[:size]
+}
+
+// isHex - whether s is of lowercase hex digits.
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntrace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nutil/nconst"
+	"github.com/stretchr/testify/assert"
+)
+
+type traceMDC interface {
+	ncontext.MDC
+	ncontext.TraceMDC
+}
+
+func extract(t *testing.T, headers map[string]string) traceMDC {
+	header := http.Header{}
+	for key, value := range headers {
+		header.Set(key, value)
+	}
+	mdc := ncontext.NewMDC().(traceMDC)
+	assert.Nil(t, Extract(mdc, header.Get))
+	assert.True(t, isValidID(mdc.SpanID(), 16))
+	return mdc
+}
+
+func injectHeader(mdc ncontext.ROMDC) http.Header {
+	header := http.Header{}
+	Inject(mdc, header.Set)
+	return header
+}
+
+func TestExtractW3C(t *testing.T) {
+	a := assert.New(t)
+	mdc := extract(t, map[string]string{
+		nconst.HeaderTraceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+		nconst.HeaderTracestate:  "congo=t61rcWkgMzE",
+		nconst.HeaderTraceID:     "legacy",
+	})
+	a.Equal("4bf92f3577b34da6a3ce929d0e0e4736", mdc.TraceID())
+	a.Equal("00f067aa0ba902b7", mdc.ParentSpanID())
+	a.Equal("congo=t61rcWkgMzE", mdc.TraceState())
+	a.False(mdc.Sampled())
+
+	header := injectHeader(mdc)
+	a.Equal("00-4bf92f3577b34da6a3ce929d0e0e4736-"+mdc.SpanID()+"-00", header.Get(nconst.HeaderTraceparent))
+	a.Equal("congo=t61rcWkgMzE", header.Get(nconst.HeaderTracestate))
+	a.Equal("4bf92f3577b34da6a3ce929d0e0e4736", header.Get(nconst.HeaderTraceID))
+	a.Empty(header.Get(nconst.HeaderB3TraceID))
+
+	// the later versions may have more fields
+	mdc = extract(t, map[string]string{nconst.HeaderTraceparent: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"})
+	a.Equal("4bf92f3577b34da6a3ce929d0e0e4736", mdc.TraceID())
+	a.True(mdc.Sampled())
+
+	for _, traceparent := range []string{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	} {
+		mdc = extract(t, map[string]string{nconst.HeaderTraceparent: traceparent, nconst.HeaderTracestate: "k=v"})
+		a.NotEqual("4bf92f3577b34da6a3ce929d0e0e4736", mdc.TraceID(), traceparent)
+		a.Empty(mdc.ParentSpanID())
+		a.Empty(mdc.TraceState())
+	}
+}
+
+func TestExtractB3(t *testing.T) {
+	a := assert.New(t)
+	InitPropagation(&nconf.Config{Trace: &nconf.TraceConfig{Propagators: []string{nconf.TracePropagatorB3, nconf.TracePropagatorW3C}}})
+	defer InitPropagation(&nconf.Config{})
+
+	mdc := extract(t, map[string]string{
+		nconst.HeaderB3:          "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-0-05e3ac9a4f6e3b90",
+		nconst.HeaderTraceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+	a.Equal("80f198ee56343ba864fe8b2a57d3eff7", mdc.TraceID())
+	a.Equal("e457b5a2e4d86bd1", mdc.ParentSpanID())
+	a.False(mdc.Sampled())
+
+	mdc = extract(t, map[string]string{
+		nconst.HeaderB3TraceID: "A3CE929D0E0E4736",
+		nconst.HeaderB3SpanID:  "00f067aa0ba902b7",
+	})
+	a.Equal("0000000000000000a3ce929d0e0e4736", mdc.TraceID())
+	a.True(mdc.Sampled())
+
+	header := injectHeader(mdc)
+	a.Equal("0000000000000000a3ce929d0e0e4736", header.Get(nconst.HeaderB3TraceID))
+	a.Equal(mdc.SpanID(), header.Get(nconst.HeaderB3SpanID))
+	a.Equal("1", header.Get(nconst.HeaderB3Sampled))
+	a.Equal("00-0000000000000000a3ce929d0e0e4736-"+mdc.SpanID()+"-01", header.Get(nconst.HeaderTraceparent))
+
+	// the invalid b3 falls back to w3c
+	mdc = extract(t, map[string]string{
+		nconst.HeaderB3:          "80f198ee56343ba8",
+		nconst.HeaderTraceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+	a.Equal("4bf92f3577b34da6a3ce929d0e0e4736", mdc.TraceID())
+}
+
+func TestExtractLegacy(t *testing.T) {
+	a := assert.New(t)
+	mdc := extract(t, map[string]string{nconst.HeaderTraceID: "6BA7B810-9DAD-11D1-80B4-00C04FD430C8"})
+	a.Equal("6BA7B810-9DAD-11D1-80B4-00C04FD430C8", mdc.TraceID())
+	a.Empty(mdc.ParentSpanID())
+	a.True(mdc.Sampled())
+	header := injectHeader(mdc)
+	a.Equal("6BA7B810-9DAD-11D1-80B4-00C04FD430C8", header.Get(nconst.HeaderTraceID))
+	a.Equal("00-6ba7b8109dad11d180b400c04fd430c8-"+mdc.SpanID()+"-01", header.Get(nconst.HeaderTraceparent))
+
+	// the UUID survives the round trip through traceparent
+	callee := extract(t, map[string]string{
+		nconst.HeaderTraceID:     header.Get(nconst.HeaderTraceID),
+		nconst.HeaderTraceparent: header.Get(nconst.HeaderTraceparent),
+	})
+	a.Equal("6BA7B810-9DAD-11D1-80B4-00C04FD430C8", callee.TraceID())
+	a.Equal(mdc.SpanID(), callee.ParentSpanID())
+	a.Equal(header.Get(nconst.HeaderTraceID), injectHeader(callee).Get(nconst.HeaderTraceID))
+	// the X-Trace-ID of another trace is ignored
+	callee = extract(t, map[string]string{
+		nconst.HeaderTraceID:     "6ba7b810-9dad-11d1-80b4-00c04fd430c9",
+		nconst.HeaderTraceparent: header.Get(nconst.HeaderTraceparent),
+	})
+	a.Equal("6ba7b8109dad11d180b400c04fd430c8", callee.TraceID())
+
+	mdc = extract(t, map[string]string{nconst.HeaderTraceID: "t1"})
+	header = injectHeader(mdc)
+	a.Equal("t1", header.Get(nconst.HeaderTraceID))
+	a.Empty(header.Get(nconst.HeaderTraceparent))
+
+	mdc = extract(t, nil)
+	a.True(isValidID(mdc.TraceID(), 32))
+	a.Empty(injectHeader(ncontext.NewMDC()))
+}
+
+func TestTransport(t *testing.T) {
+	a := assert.New(t)
+	var traceparent, timeout string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get(nconst.HeaderTraceparent)
+		timeout = r.Header.Get(nconst.HeaderTimeout)
+	}))
+	defer srv.Close()
+
+	mdc := extract(t, map[string]string{nconst.HeaderTraceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"})
+	req, _ := http.NewRequestWithContext(ncontext.WithMDC(context.Background(), mdc), http.MethodGet, srv.URL, nil)
+	resp, err := (&http.Client{Transport: NewTransport(nil)}).Do(req)
+	a.Nil(err)
+	resp.Body.Close()
+	a.Equal("00-4bf92f3577b34da6a3ce929d0e0e4736-"+mdc.SpanID()+"-01", traceparent)
+	a.Empty(req.Header.Get(nconst.HeaderTraceparent))
+	a.Empty(timeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	resp, err = (&http.Client{Transport: NewTransport(nil)}).Do(req)
+	a.Nil(err)
+	resp.Body.Close()
+	budget, ok := ncontext.ParseBudget(timeout)
+	a.True(ok)
+	a.True(budget > 59*time.Second && budget <= time.Minute)
+	a.Empty(traceparent)
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntrace
+
+import (
+	"context"
+	"crypto/rand"
+	"sync/atomic"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/nf-go/nfgo/nlog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/nf-go/nfgo"
+
+var tracing atomic.Pointer[tracer]
+
+type tracer struct {
+	trace.Tracer
+	provider *sdktrace.TracerProvider
+	memory   *tracetest.InMemoryExporter
+}
+
+// InitTracing - creates the spans by OpenTelemetry if trace.enabled, otherwise the spans are noop.
+// The tracer provider is set as the global one of otel as well, and the last one is shut down.
+func InitTracing(config *nconf.Config) error {
+	traceConf := config.Trace
+	if traceConf == nil || !traceConf.Enabled {
+		return Shutdown(context.Background())
+	}
+
+	var memory *tracetest.InMemoryExporter
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch traceConf.Exporter {
+	case nconf.TraceExporterStdout:
+		exporter, err = stdouttrace.New()
+	case nconf.TraceExporterMemory:
+		memory = tracetest.NewInMemoryExporter()
+		exporter = memory
+	default:
+		opts := []otlptracegrpc.Option{}
+		if traceConf.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(traceConf.Endpoint))
+		}
+		if traceConf.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(context.Background(), opts...)
+	}
+	if err != nil {
+		return nerrors.Wrap(err, "fail to new the trace exporter")
+	}
+
+	ratio := 1.0
+	if traceConf.SampleRatio != nil {
+		ratio = *traceConf.SampleRatio
+	}
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithIDGenerator(idGenerator{}),
+	}
+	if app := config.App; app != nil {
+		opts = append(opts, sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(app.Name),
+			semconv.DeploymentEnvironmentName(app.Profile),
+		)))
+	}
+	if memory != nil {
+		// the spans are exported once they end
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+	} else {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+
+	last := tracing.Swap(&tracer{provider.Tracer(instrumentationName), provider, memory})
+	if last != nil {
+		return last.provider.Shutdown(context.Background())
+	}
+	return nil
+}
+
+// MustInitTracing -
+func MustInitTracing(config *nconf.Config) {
+	if err := InitTracing(config); err != nil {
+		nlog.Fatal("fail to init tracing: ", err)
+	}
+}
+
+// Shutdown - exports the ended spans and disables the tracing, it is called by the nfgo server on shutdown.
+func Shutdown(ctx context.Context) error {
+	if t := tracing.Swap(nil); t != nil {
+		return t.provider.Shutdown(ctx)
+	}
+	return nil
+}
+
+// Enabled - whether the spans are created, see InitTracing.
+func Enabled() bool {
+	return tracing.Load() != nil
+}
+
+// MemoryExporter - the exporter of trace.exporter memory, it is nil for the other exporters.
+func MemoryExporter() *tracetest.InMemoryExporter {
+	if t := tracing.Load(); t != nil {
+		return t.memory
+	}
+	return nil
+}
+
+// StartEntrySpan - starts the span of the request or the job which owns the MDC in ctx. The span is the child of
+// the caller bound to the MDC by Extract, and the trace id, span id, sampled flag and trace state of the MDC are
+// replaced by the ones of the span, so that the logs and the outgoing requests carry the span.
+// It returns ctx and a noop span if the tracing is disabled.
+func StartEntrySpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t := tracing.Load()
+	if t == nil {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+	romdc, _ := ncontext.CurrentMDC(ctx)
+	mdc, ok := romdc.(ncontext.MDC)
+	if !ok {
+		return t.Start(ctx, name, opts...)
+	}
+
+	tmdc := ncontext.TraceOf(mdc)
+	ctx, span := t.Start(parentContext(ctx, mdc, tmdc.ParentSpanID()), name, opts...)
+	sc := span.SpanContext()
+	if sc.IsValid() {
+		// the UUID of the same trace is kept for the logs, see keepTraceID
+		if w3cID, _ := w3cTraceID(mdc.TraceID()); w3cID != sc.TraceID().String() {
+			mdc.SetTraceID(sc.TraceID().String())
+		}
+		tmdc.SetSpanID(sc.SpanID().String())
+		tmdc.SetSampled(sc.IsSampled())
+		tmdc.SetTraceState(sc.TraceState().String())
+	}
+	return ctx, span
+}
+
+// StartSpan - starts the span as the child of the span in ctx, or of the span of the MDC in ctx if there is no span,
+// such as the ctx detached by ncontext.Background. It returns ctx and a noop span if the tracing is disabled.
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t := tracing.Load()
+	if t == nil {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+	if mdc, _ := ncontext.CurrentMDC(ctx); mdc != nil {
+		ctx = parentContext(ctx, mdc, ncontext.ROTraceOf(mdc).SpanID())
+	}
+	return t.Start(ctx, name, opts...)
+}
+
+// EndSpan - records err and ends the span, the status of the span is error unless err is nil or a BizError.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if bizErr, ok := err.(nerrors.BizError); ok {
+			span.SetAttributes(attribute.Int("nfgo.biz_error.code", bizErr.Code()))
+		} else {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+// parentContext - the remote span of the trace of the MDC is set to ctx as the parent if there is no span in ctx.
+// If there is no such span, the trace id of the MDC is kept for the root span, see idGenerator.
+func parentContext(ctx context.Context, mdc ncontext.ROMDC, spanID string) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	w3cID, ok := w3cTraceID(mdc.TraceID())
+	if !ok {
+		return ctx
+	}
+	traceID, _ := trace.TraceIDFromHex(w3cID)
+	parentID, err := trace.SpanIDFromHex(spanID)
+	if err != nil {
+		return context.WithValue(ctx, traceIDKey{}, traceID)
+	}
+	tmdc := ncontext.ROTraceOf(mdc)
+	var flags trace.TraceFlags
+	if tmdc.Sampled() {
+		flags = trace.FlagsSampled
+	}
+	traceState, _ := trace.ParseTraceState(tmdc.TraceState())
+	return trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     parentID,
+		TraceFlags: flags,
+		TraceState: traceState,
+		Remote:     true,
+	}))
+}
+
+type traceIDKey struct{}
+
+// idGenerator - the root spans keep the trace id in the context, which is the one of the MDC.
+type idGenerator struct{}
+
+func (g idGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	traceID, ok := ctx.Value(traceIDKey{}).(trace.TraceID)
+	for !ok || !traceID.IsValid() {
+		_, _ = rand.Read(traceID[:])
+		ok = true
+	}
+	return traceID, g.NewSpanID(ctx, traceID)
+}
+
+func (idGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	var spanID trace.SpanID
+	for !spanID.IsValid() {
+		_, _ = rand.Read(spanID[:])
+	}
+	return spanID
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntrace
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/nf-go/nfgo/nutil/nconst"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func initMemoryTracing(t *testing.T) {
+	ratio := 0.0
+	err := InitTracing(&nconf.Config{
+		App:   &nconf.AppConfig{Name: "foo-app"},
+		Trace: &nconf.TraceConfig{Enabled: true, Exporter: nconf.TraceExporterMemory, SampleRatio: &ratio},
+	})
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		assert.Nil(t, Shutdown(context.Background()))
+	})
+}
+
+func TestStartEntrySpan(t *testing.T) {
+	a := assert.New(t)
+	initMemoryTracing(t)
+
+	mdc := extract(t, map[string]string{
+		nconst.HeaderTraceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		nconst.HeaderTracestate:  "congo=t61rcWkgMzE",
+	})
+	ctx, span := StartEntrySpan(ncontext.WithMDC(context.Background(), mdc), "GET /users/:id", trace.WithSpanKind(trace.SpanKindServer))
+	a.Equal("4bf92f3577b34da6a3ce929d0e0e4736", mdc.TraceID())
+	a.Equal(span.SpanContext().SpanID().String(), mdc.SpanID())
+	a.Equal("00f067aa0ba902b7", mdc.ParentSpanID())
+	a.True(mdc.Sampled())
+
+	childCtx, child := StartSpan(ctx, "gorm.query")
+	header := http.Header{}
+	InjectContext(childCtx, header.Set)
+	a.Equal("00-4bf92f3577b34da6a3ce929d0e0e4736-"+child.SpanContext().SpanID().String()+"-01", header.Get(nconst.HeaderTraceparent))
+	a.Equal("congo=t61rcWkgMzE", header.Get(nconst.HeaderTracestate))
+	EndSpan(child, nerrors.New("connection refused"))
+
+	// the span of the MDC is the parent of the detached ctx
+	_, detached := StartSpan(ncontext.Background(ctx), "redis.GET")
+	EndSpan(detached, nerrors.ErrForbidden)
+	EndSpan(span, nil)
+
+	spans := MemoryExporter().GetSpans()
+	a.Len(spans, 3)
+	a.Equal("gorm.query", spans[0].Name)
+	a.Equal(span.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	a.Equal(codes.Error, spans[0].Status.Code)
+	a.Equal("redis.GET", spans[1].Name)
+	a.Equal(span.SpanContext().SpanID(), spans[1].Parent.SpanID())
+	a.Equal(codes.Unset, spans[1].Status.Code)
+	a.Equal("GET /users/:id", spans[2].Name)
+	a.True(spans[2].Parent.IsRemote())
+	a.Equal("00f067aa0ba902b7", spans[2].Parent.SpanID().String())
+	serviceName, _ := spans[2].Resource.Set().Value(semconv.ServiceNameKey)
+	a.Equal("foo-app", serviceName.AsString())
+}
+
+func TestStartEntrySpanOfNewTrace(t *testing.T) {
+	a := assert.New(t)
+	initMemoryTracing(t)
+
+	// the trace id of the UUID is kept, and the root span is sampled by trace.sampleRatio
+	mdc := extract(t, map[string]string{nconst.HeaderTraceID: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"})
+	_, span := StartEntrySpan(ncontext.WithMDC(context.Background(), mdc), "job foo")
+	a.Equal("6ba7b810-9dad-11d1-80b4-00c04fd430c8", mdc.TraceID())
+	a.Equal("6ba7b8109dad11d180b400c04fd430c8", span.SpanContext().TraceID().String())
+	a.Equal(span.SpanContext().SpanID().String(), mdc.SpanID())
+	a.False(mdc.Sampled())
+	span.End()
+	a.Empty(MemoryExporter().GetSpans())
+
+	// the trace id which is not of W3C is replaced
+	mdc = extract(t, map[string]string{nconst.HeaderTraceID: "t1"})
+	StartEntrySpan(ncontext.WithMDC(context.Background(), mdc), "job foo")
+	a.True(isValidID(mdc.TraceID(), 32))
+}
+
+func TestTracingDisabled(t *testing.T) {
+	a := assert.New(t)
+	a.Nil(InitTracing(&nconf.Config{Trace: &nconf.TraceConfig{}}))
+	a.False(Enabled())
+	a.Nil(MemoryExporter())
+
+	mdc := extract(t, map[string]string{nconst.HeaderTraceID: "t1"})
+	spanID := mdc.SpanID()
+	ctx := ncontext.WithMDC(context.Background(), mdc)
+	entryCtx, span := StartEntrySpan(ctx, "GET /")
+	a.Equal(ctx, entryCtx)
+	a.False(span.IsRecording())
+	a.Equal("t1", mdc.TraceID())
+	a.Equal(spanID, mdc.SpanID())
+}
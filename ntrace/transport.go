@@ -0,0 +1,54 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntrace
+
+import (
+	"net/http"
+
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nutil/nconst"
+)
+
+// NewTransport - the http.RoundTripper which sets the trace headers of the MDC in the context of the requests,
+// see InjectContext, and the remaining budget of the deadline of the context as the X-Timeout header.
+// base defaults to http.DefaultTransport.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	_, err := ncontext.CurrentMDC(ctx)
+	budget, ok := ncontext.Budget(ctx)
+	if err != nil && !ok {
+		return t.base.RoundTrip(req)
+	}
+	// a RoundTripper must not modify the request
+	req = req.Clone(ctx)
+	if err == nil {
+		InjectContext(ctx, req.Header.Set)
+	}
+	if ok {
+		req.Header.Set(nconst.HeaderTimeout, ncontext.FormatBudget(budget))
+	}
+	return t.base.RoundTrip(req)
+}
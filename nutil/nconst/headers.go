@@ -31,4 +31,26 @@ const (
 	HeaderSig string = "X-Sig"
 	// HeaderClientType -
 	HeaderClientType string = "X-ClientType"
+	// HeaderDebug - log.traceDebugToken flags the trace as debug if log.traceDebug is enabled
+	HeaderDebug string = "X-Debug"
+	// HeaderTimeout - the remaining budget of the request in milliseconds, 0 means the deadline is exceeded
+	HeaderTimeout string = "X-Timeout"
+	// HeaderTraceparent - the trace context of W3C Trace Context, version-traceID-parentID-flags
+	HeaderTraceparent string = "traceparent"
+	// HeaderTracestate - the vendor specific trace info of W3C Trace Context
+	HeaderTracestate string = "tracestate"
+	// HeaderBaggage - the entries of W3C Baggage, key1=value1,key2=value2
+	HeaderBaggage string = "baggage"
+	// HeaderB3 - the single header of B3 propagation, traceID-spanID-sampled-parentSpanID
+	HeaderB3 string = "b3"
+	// HeaderB3TraceID -
+	HeaderB3TraceID string = "X-B3-TraceId"
+	// HeaderB3SpanID -
+	HeaderB3SpanID string = "X-B3-SpanId"
+	// HeaderB3ParentSpanID -
+	HeaderB3ParentSpanID string = "X-B3-ParentSpanId"
+	// HeaderB3Sampled - 1 or 0
+	HeaderB3Sampled string = "X-B3-Sampled"
+	// HeaderB3Flags - 1 means debug, which implies sampled
+	HeaderB3Flags string = "X-B3-Flags"
 )
@@ -64,6 +64,9 @@ func AESDecrypt(encryptedData []byte, key []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if len(encryptedData) < aesGCMDefaultNonceSizeBytes {
+		return nil, nerrors.New("failed to decrypt value: the data is too short")
+	}
 	plainData, err := gcm.Open(nil, encryptedData[:aesGCMDefaultNonceSizeBytes], encryptedData[aesGCMDefaultNonceSizeBytes:], nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt value: %v", err)
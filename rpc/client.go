@@ -22,6 +22,7 @@ import (
 
 	"github.com/nf-go/nfgo/nconf"
 	"github.com/nf-go/nfgo/nlog"
+	"github.com/nf-go/nfgo/ntrace"
 	"github.com/nf-go/nfgo/nutil/ntypes"
 	"github.com/nf-go/nfgo/rpc/interceptor"
 	"google.golang.org/grpc"
@@ -60,6 +61,7 @@ func NewClientConns(config *nconf.Config) (ClientConns, error) {
 	if config.RPC == nil {
 		return nil, errors.New("rpc config is not initialized in the config")
 	}
+	ntrace.InitPropagation(config)
 	conns := clientConns(map[string]*grpc.ClientConn{})
 	for svcName, clientConf := range config.RPC.Clients {
 		conn, err := dialConn(clientConf)
@@ -87,14 +89,18 @@ func dialConn(config *nconf.RPCClientConfig) (*grpc.ClientConn, error) {
 			grpc.MaxCallRecvMsgSize(config.MaxCallRecvMsgSize),
 		),
 		grpc.WithChainUnaryInterceptor(
+			interceptor.TracingUnaryClientInterceptor,
 			interceptor.MDCBindingUnaryClientInterceptor,
 			interceptor.ValidateUnaryClientInterceptor,
 			interceptor.LoggingUnaryClientInterceptor,
+			interceptor.DeadlineUnaryClientInterceptor(config.Timeout),
 		),
 		grpc.WithChainStreamInterceptor(
+			interceptor.TracingStreamClientInterceptor,
 			interceptor.MDCBindingStreamClientInterceptor,
 			interceptor.ValidateStreamClientInterceptor,
 			interceptor.LoggingStreamClientInterceptor,
+			interceptor.DeadlineStreamClientInterceptor(config.Timeout),
 		),
 	}
 	if ntypes.BoolValue(config.Plaintext) {
@@ -0,0 +1,100 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"google.golang.org/grpc"
+)
+
+// DeadlineUnaryServerInterceptor - applies the timeout of the method to the context, which is shortened by the
+// deadline propagated by the caller, see rpc.timeout and rpc.methodTimeouts. The call fails fast with
+// nerrors.ErrDeadlineExceeded if the deadline is exceeded. It should be after ErrorHandleUnaryServerInterceptor.
+func DeadlineUnaryServerInterceptor(conf *nconf.RPCConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := ncontext.CheckDeadline(ctx); err != nil {
+			return nil, err
+		}
+		ctx, cancel := ncontext.WithTimeout(ctx, conf.MethodTimeout(info.FullMethod))
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// DeadlineStreamServerInterceptor - see DeadlineUnaryServerInterceptor.
+func DeadlineStreamServerInterceptor(conf *nconf.RPCConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := ncontext.CheckDeadline(stream.Context()); err != nil {
+			return err
+		}
+		ctx, cancel := ncontext.WithTimeout(stream.Context(), conf.MethodTimeout(info.FullMethod))
+		defer cancel()
+		return handler(srv, &serverStreamWrapper{stream: stream, ctx: ctx})
+	}
+}
+
+// DeadlineUnaryClientInterceptor - applies the timeout to the calls whose context has no deadline,
+// the deadline is propagated to the server by grpc. The call fails fast with nerrors.ErrDeadlineExceeded
+// if the deadline is exceeded.
+func DeadlineUnaryClientInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req interface{}, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := ncontext.CheckDeadline(ctx); err != nil {
+			return err
+		}
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// DeadlineStreamClientInterceptor - see DeadlineUnaryClientInterceptor, the timeout covers the whole stream.
+func DeadlineStreamClientInterceptor(timeout time.Duration) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := ncontext.CheckDeadline(ctx); err != nil {
+			return nil, err
+		}
+		if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		return &deadlineClientStream{ClientStream: stream, cancel: cancel}, nil
+	}
+}
+
+// deadlineClientStream - releases the timer of the timeout when RecvMsg returns an error including io.EOF.
+type deadlineClientStream struct {
+	grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+func (s *deadlineClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.cancel()
+	}
+	return err
+}
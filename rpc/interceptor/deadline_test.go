@@ -0,0 +1,176 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	checkMethod = "/grpc.health.v1.Health/Check"
+	watchMethod = "/grpc.health.v1.Health/Watch"
+)
+
+func TestDeadlineUnaryServerInterceptor(t *testing.T) {
+	a := assert.New(t)
+	budgets := make(chan time.Duration, 1)
+	srv := &testHealthServer{check: func(ctx context.Context, req *healthpb.HealthCheckRequest) error {
+		budget, ok := ncontext.Budget(ctx)
+		a.True(ok)
+		budgets <- budget
+		return nil
+	}}
+	conf := &nconf.RPCConfig{Timeout: time.Second, MethodTimeouts: map[string]time.Duration{checkMethod: time.Minute}}
+	client := newTestClient(t, srv, []grpc.ServerOption{grpc.ChainUnaryInterceptor(DeadlineUnaryServerInterceptor(conf))})
+
+	// the timeout of the method
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	a.Nil(err)
+	budget := <-budgets
+	a.True(budget > 50*time.Second && budget <= time.Minute, budget)
+
+	// the deadline propagated by the caller is shorter than the timeout of the method
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	a.Nil(err)
+	budget = <-budgets
+	a.True(budget > 0 && budget <= 2*time.Second, budget)
+}
+
+func TestDeadlineUnaryServerInterceptorFailFast(t *testing.T) {
+	a := assert.New(t)
+	var called atomic.Bool
+	srv := &testHealthServer{check: func(ctx context.Context, req *healthpb.HealthCheckRequest) error {
+		called.Store(true)
+		return nil
+	}}
+	// the request waits in the server until the deadline propagated by the caller is exceeded
+	errs := make(chan error, 1)
+	slow := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		deadline, _ := ctx.Deadline()
+		time.Sleep(time.Until(deadline))
+		resp, err := handler(ctx, req)
+		errs <- err
+		return resp, err
+	}
+	client := newTestClient(t, srv, []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(slow, DeadlineUnaryServerInterceptor(&nconf.RPCConfig{Timeout: time.Minute})),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	a.Equal(codes.DeadlineExceeded, status.Code(err))
+	a.Equal(nerrors.ErrDeadlineExceeded, <-errs)
+	a.False(called.Load())
+}
+
+func TestDeadlineUnaryClientInterceptor(t *testing.T) {
+	a := assert.New(t)
+	budgets := make(chan time.Duration, 1)
+	srv := &testHealthServer{check: func(ctx context.Context, req *healthpb.HealthCheckRequest) error {
+		budget, ok := ncontext.Budget(ctx)
+		a.True(ok)
+		budgets <- budget
+		return nil
+	}}
+	client := newTestClient(t, srv, nil, grpc.WithChainUnaryInterceptor(DeadlineUnaryClientInterceptor(2*time.Second)))
+
+	// the timeout of the client is propagated to the server
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	a.Nil(err)
+	budget := <-budgets
+	a.True(budget > 0 && budget <= 2*time.Second, budget)
+
+	// the deadline of the caller is kept
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{})
+	a.Nil(err)
+	budget = <-budgets
+	a.True(budget > 2*time.Second, budget)
+
+	// the exhausted budget fails fast without calling the server
+	expiredCtx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err = client.Check(expiredCtx, &healthpb.HealthCheckRequest{})
+	a.Equal(nerrors.ErrDeadlineExceeded, err)
+	a.Empty(budgets)
+}
+
+func TestDeadlineStreamInterceptors(t *testing.T) {
+	a := assert.New(t)
+	budgets := make(chan time.Duration, 1)
+	srv := &testHealthServer{watch: func(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+		budget, ok := ncontext.Budget(stream.Context())
+		a.True(ok)
+		budgets <- budget
+		return status.Error(codes.Unavailable, "watch failed")
+	}}
+	// the ctx of the stream created by DeadlineStreamClientInterceptor
+	streamCtxs := make(chan context.Context, 1)
+	capture := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		streamCtxs <- ctx
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	conf := &nconf.RPCConfig{MethodTimeouts: map[string]time.Duration{watchMethod: 30 * time.Second}}
+	client := newTestClient(t, srv,
+		[]grpc.ServerOption{grpc.ChainStreamInterceptor(DeadlineStreamServerInterceptor(conf))},
+		grpc.WithChainStreamInterceptor(DeadlineStreamClientInterceptor(time.Minute), capture))
+
+	stream, err := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	a.Nil(err)
+	streamCtx := <-streamCtxs
+	_, ok := streamCtx.Deadline()
+	a.True(ok)
+	a.Nil(streamCtx.Err())
+	// the timeout of the client is released on the first error of RecvMsg
+	_, err = stream.Recv()
+	a.Equal(codes.Unavailable, status.Code(err))
+	a.Equal(context.Canceled, streamCtx.Err())
+	// the timeout of the method is shorter than the deadline propagated by the client
+	budget := <-budgets
+	a.True(budget > 20*time.Second && budget <= 30*time.Second, budget)
+
+	// the deadline propagated by the client is shorter than the timeout of the method
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	stream, err = client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	a.Nil(err)
+	a.Equal(ctx, <-streamCtxs)
+	_, err = stream.Recv()
+	a.Equal(codes.Unavailable, status.Code(err))
+	budget = <-budgets
+	a.True(budget > 0 && budget <= 2*time.Second, budget)
+
+	expiredCtx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err = client.Watch(expiredCtx, &healthpb.HealthCheckRequest{})
+	a.Equal(nerrors.ErrDeadlineExceeded, err)
+	a.Empty(streamCtxs)
+}
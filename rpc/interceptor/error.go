@@ -16,6 +16,7 @@ package interceptor
 
 import (
 	"context"
+	"errors"
 
 	"github.com/nf-go/nfgo/nerrors"
 	"github.com/nf-go/nfgo/nlog"
@@ -40,6 +41,11 @@ func ErrorHandleStreamServerInterceptor(srv interface{}, stream grpc.ServerStrea
 
 func handleServerError(ctx context.Context, err error) error {
 	if err != nil {
+		// the work given up by the deadline, such as a downstream call, fails as the deadline biz error
+		if _, ok := err.(nerrors.BizError); !ok &&
+			(errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded) {
+			err = nerrors.ErrDeadlineExceeded
+		}
 		logger := nlog.Logger(ctx).WithError(err)
 
 		// logging biz error
@@ -21,6 +21,8 @@ import (
 	"github.com/nf-go/nfgo/nerrors"
 	"github.com/nf-go/nfgo/nlog"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 // LoggingUnaryServerInterceptor -
@@ -29,16 +31,16 @@ func LoggingUnaryServerInterceptor(ctx context.Context, req interface{}, info *g
 	logger := nlog.Logger(ctx)
 
 	// logging req
-	if stringer, ok := req.(fmt.Stringer); ok {
-		logger.WithField(fieldNameReq, stringer.String()).Info()
+	if msg, ok := msgString(req); ok {
+		logger.WithField(fieldNameReq, msg).Info()
 	}
 
 	resp, err = handler(ctx, req)
 
 	// logging resp
 	if err == nil && logger.IsLevelEnabled(nlog.DebugLevel) {
-		if stringer, ok := resp.(fmt.Stringer); ok {
-			logger.WithField(fieldNameResp, stringer.String()).Debug()
+		if msg, ok := msgString(resp); ok {
+			logger.WithField(fieldNameResp, msg).Debug()
 		}
 	}
 
@@ -72,18 +74,18 @@ func LoggingUnaryClientInterceptor(ctx context.Context, method string, req inter
 				errLogger.Error()
 			}
 		} else if logger.IsLevelEnabled(nlog.DebugLevel) {
-			if stringer, ok := reply.(fmt.Stringer); ok {
+			if msg, ok := msgString(reply); ok {
 				logger.WithFields(nlog.Fields{
 					fieldNameRPCCall: method,
-					fieldNameResp:    stringer.String(),
+					fieldNameResp:    msg,
 				}).Debug()
 			}
 		}
 
 	}()
-	if stringer, ok := req.(fmt.Stringer); ok {
+	if msg, ok := msgString(req); ok {
 		nlog.Logger(ctx).WithFields(nlog.Fields{
-			fieldNameReq:     stringer.String(),
+			fieldNameReq:     msg,
 			fieldNameRPCCall: method,
 		}).Info()
 	}
@@ -104,3 +106,17 @@ func LoggingStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc,
 	}
 	return stream, err
 }
+
+// msgString - the string of the msg to be logged, the proto message is logged as JSON
+// redacted by log.redaction if there is any redaction rule.
+func msgString(m interface{}) (string, bool) {
+	if pm, ok := m.(proto.Message); ok && nlog.RedactionEnabled() {
+		if data, err := protojson.Marshal(pm); err == nil {
+			return string(nlog.RedactJSON(data)), true
+		}
+	}
+	if stringer, ok := m.(fmt.Stringer); ok {
+		return stringer.String(), true
+	}
+	return "", false
+}
@@ -18,8 +18,9 @@ import (
 	"context"
 
 	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nlog"
+	"github.com/nf-go/nfgo/ntrace"
 	"github.com/nf-go/nfgo/nutil/nconst"
-	"github.com/nf-go/nfgo/nutil/ncrypto"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -28,13 +29,7 @@ import (
 // MDCBindingUnaryClientInterceptor -
 func MDCBindingUnaryClientInterceptor(ctx context.Context, method string, req interface{}, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	if mdc, err := ncontext.CurrentMDC(ctx); err == nil {
-		kv := []string{
-			nconst.HeaderTraceID, mdc.TraceID(),
-			nconst.HeaderRealIP, mdc.ClientIP(),
-			nconst.HeaderClientType, mdc.ClientType(),
-			nconst.HeaderSub, mdc.SubjectID(),
-		}
-		ctx = metadata.AppendToOutgoingContext(ctx, kv...)
+		ctx = appendMDCToOutgoingContext(ctx, mdc)
 	}
 
 	return invoker(ctx, method, req, reply, cc, opts...)
@@ -43,13 +38,7 @@ func MDCBindingUnaryClientInterceptor(ctx context.Context, method string, req in
 // MDCBindingStreamClientInterceptor -
 func MDCBindingStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
 	if mdc, err := ncontext.CurrentMDC(ctx); err == nil {
-		kv := []string{
-			nconst.HeaderTraceID, mdc.TraceID(),
-			nconst.HeaderRealIP, mdc.ClientIP(),
-			nconst.HeaderClientType, mdc.ClientType(),
-			nconst.HeaderSub, mdc.SubjectID(),
-		}
-		ctx = metadata.AppendToOutgoingContext(ctx, kv...)
+		ctx = appendMDCToOutgoingContext(ctx, mdc)
 	}
 	return streamer(ctx, desc, cc, method, opts...)
 }
@@ -73,6 +62,23 @@ func MDCBindingStreamServerInterceptor(srv interface{}, stream grpc.ServerStream
 	return handler(srv, s)
 }
 
+func appendMDCToOutgoingContext(ctx context.Context, mdc ncontext.ROMDC) context.Context {
+	kv := []string{
+		nconst.HeaderRealIP, mdc.ClientIP(),
+		nconst.HeaderClientType, mdc.ClientType(),
+		nconst.HeaderSub, mdc.SubjectID(),
+	}
+	if ncontext.ROTraceOf(mdc).Debug() {
+		if debug := nlog.TraceDebugHeader(); debug != "" {
+			kv = append(kv, nconst.HeaderDebug, debug)
+		}
+	}
+	ntrace.InjectContext(ctx, func(key string, value string) {
+		kv = append(kv, key, value)
+	})
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
+
 func getHeader(md metadata.MD, name string) string {
 	values := md.Get(name)
 	if len(values) > 0 {
@@ -82,30 +88,29 @@ func getHeader(md metadata.MD, name string) string {
 }
 
 func bindMDCToContext(ctx context.Context, fullMethodName string) (context.Context, error) {
-	var traceID string
 	var clinetIP string
 	var clientType string
 	var subject string
+	var debug bool
 	md, ok := metadata.FromIncomingContext(ctx)
 	if ok {
-		traceID = getHeader(md, nconst.HeaderTraceID)
 		clinetIP = getHeader(md, nconst.HeaderRealIP)
 		clientType = getHeader(md, nconst.HeaderClientType)
 		subject = getHeader(md, nconst.HeaderSub)
-	}
-	if traceID == "" {
-		var err error
-		if traceID, err = ncrypto.UUID(); err != nil {
-			return nil, err
-		}
+		debug = nlog.IsTraceDebugHeader(getHeader(md, nconst.HeaderDebug))
 	}
 
 	mdc := ncontext.NewMDC()
-	mdc.SetTraceID(traceID)
+	if err := ntrace.Extract(mdc, func(key string) string {
+		return getHeader(md, key)
+	}); err != nil {
+		return nil, err
+	}
 	mdc.SetClientIP(clinetIP)
 	mdc.SetClientType(clientType)
 	mdc.SetRPCName(fullMethodName)
 	mdc.SetSubjectID(subject)
+	ncontext.TraceOf(mdc).SetDebug(debug)
 
 	return ncontext.WithMDC(ctx, mdc), nil
 }
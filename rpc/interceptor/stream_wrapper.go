@@ -16,7 +16,6 @@ package interceptor
 
 import (
 	"context"
-	"fmt"
 	"io"
 
 	"github.com/nf-go/nfgo/nlog"
@@ -58,8 +57,8 @@ func (s *serverStreamWrapper) SetTrailer(md metadata.MD) {
 
 func (s *serverStreamWrapper) SendMsg(m interface{}) (err error) {
 	if s.logMsg {
-		if stringer, ok := m.(fmt.Stringer); ok {
-			nlog.Logger(s.ctx).WithField("resp", stringer.String()).Info("server stream send msg.")
+		if msg, ok := msgString(m); ok {
+			nlog.Logger(s.ctx).WithField("resp", msg).Info("server stream send msg.")
 		}
 	}
 	return s.stream.SendMsg(m)
@@ -69,8 +68,8 @@ func (s *serverStreamWrapper) RecvMsg(m interface{}) error {
 	err := s.stream.RecvMsg(m)
 
 	if s.logMsg {
-		if stringer, ok := m.(fmt.Stringer); ok {
-			nlog.Logger(s.ctx).WithField(fieldNameReq, stringer.String()).Info("server stream recv msg.")
+		if msg, ok := msgString(m); ok {
+			nlog.Logger(s.ctx).WithField(fieldNameReq, msg).Info("server stream recv msg.")
 		}
 	}
 
@@ -111,9 +110,9 @@ func (s *clientStreamWrapper) Context() context.Context {
 
 func (s *clientStreamWrapper) SendMsg(m interface{}) error {
 	if s.logMsg {
-		if stringer, ok := m.(fmt.Stringer); ok {
+		if msg, ok := msgString(m); ok {
 			nlog.Logger(s.Context()).WithFields(nlog.Fields{
-				fieldNameReq:     stringer.String(),
+				fieldNameReq:     msg,
 				fieldNameRPCCall: s.method,
 			}).Info("clent stream send msg.")
 		}
@@ -130,9 +129,9 @@ func (s *clientStreamWrapper) SendMsg(m interface{}) error {
 
 func (s *clientStreamWrapper) RecvMsg(m interface{}) error {
 	if s.logMsg {
-		if stringer, ok := m.(fmt.Stringer); ok {
+		if msg, ok := msgString(m); ok {
 			nlog.Logger(s.Context()).WithFields(nlog.Fields{
-				fieldNameResp:    stringer.String(),
+				fieldNameResp:    msg,
 				fieldNameRPCCall: s.method,
 			}).Info("client stream recv msg.")
 		}
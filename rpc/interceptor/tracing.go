@@ -0,0 +1,132 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/nf-go/nfgo/ntrace"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TracingUnaryServerInterceptor - starts the server span if the tracing is enabled, see ntrace.StartEntrySpan.
+// It should be after MDCBindingUnaryServerInterceptor.
+func TracingUnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	if !ntrace.Enabled() {
+		return handler(ctx, req)
+	}
+	ctx, span := ntrace.StartEntrySpan(ctx, info.FullMethod, rpcSpanOptions(trace.SpanKindServer, info.FullMethod)...)
+	defer func() {
+		endRPCSpan(span, trace.SpanKindServer, err)
+	}()
+	return handler(ctx, req)
+}
+
+// TracingStreamServerInterceptor - see TracingUnaryServerInterceptor.
+func TracingStreamServerInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	if !ntrace.Enabled() {
+		return handler(srv, stream)
+	}
+	ctx, span := ntrace.StartEntrySpan(stream.Context(), info.FullMethod, rpcSpanOptions(trace.SpanKindServer, info.FullMethod)...)
+	defer func() {
+		endRPCSpan(span, trace.SpanKindServer, err)
+	}()
+	return handler(srv, &serverStreamWrapper{stream: stream, ctx: ctx})
+}
+
+// TracingUnaryClientInterceptor - starts the client span if the tracing is enabled, see ntrace.StartSpan.
+// It should be before MDCBindingUnaryClientInterceptor, which sends the span as the parent of the server span.
+func TracingUnaryClientInterceptor(ctx context.Context, method string, req interface{}, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !ntrace.Enabled() {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	ctx, span := ntrace.StartSpan(ctx, method, rpcSpanOptions(trace.SpanKindClient, method)...)
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	endRPCSpan(span, trace.SpanKindClient, err)
+	return err
+}
+
+// TracingStreamClientInterceptor - see TracingUnaryClientInterceptor.
+// The span ends when the stream fails to be created, or RecvMsg returns an error including io.EOF.
+func TracingStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if !ntrace.Enabled() {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	ctx, span := ntrace.StartSpan(ctx, method, rpcSpanOptions(trace.SpanKindClient, method)...)
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		endRPCSpan(span, trace.SpanKindClient, err)
+		return nil, err
+	}
+	return &tracingClientStream{ClientStream: stream, span: span}, nil
+}
+
+type tracingClientStream struct {
+	grpc.ClientStream
+	span trace.Span
+	once sync.Once
+}
+
+func (s *tracingClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.once.Do(func() {
+			if err == io.EOF {
+				endRPCSpan(s.span, trace.SpanKindClient, nil)
+				return
+			}
+			endRPCSpan(s.span, trace.SpanKindClient, err)
+		})
+	}
+	return err
+}
+
+// rpcSpanOptions - the full method is /package.service/method.
+func rpcSpanOptions(kind trace.SpanKind, fullMethod string) []trace.SpanStartOption {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return []trace.SpanStartOption{
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(semconv.RPCSystemGRPC, semconv.RPCService(service), semconv.RPCMethod(method)),
+	}
+}
+
+// endRPCSpan - the status of the server span is error only if the code means a server fault.
+func endRPCSpan(span trace.Span, kind trace.SpanKind, err error) {
+	st := status.Convert(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	if err != nil {
+		span.RecordError(err)
+		if kind == trace.SpanKindClient || isServerFault(st.Code()) {
+			span.SetStatus(otelcodes.Error, st.Message())
+		}
+	}
+	span.End()
+}
+
+func isServerFault(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	}
+	return false
+}
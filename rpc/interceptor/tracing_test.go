@@ -0,0 +1,205 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interceptor
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/ntrace"
+	"github.com/stretchr/testify/assert"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testHealthServer - the health service whose Check and Watch are replaced by the tests.
+type testHealthServer struct {
+	healthpb.UnimplementedHealthServer
+	check func(ctx context.Context, req *healthpb.HealthCheckRequest) error
+	watch func(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error
+}
+
+func (s *testHealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if err := s.check(ctx, req); err != nil {
+		return nil, err
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *testHealthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	return s.watch(req, stream)
+}
+
+// newTestClient - serves srv by the in-memory connection, and returns the client of it.
+func newTestClient(t *testing.T, srv healthpb.HealthServer, serverOpts []grpc.ServerOption, dialOpts ...grpc.DialOption) healthpb.HealthClient {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(serverOpts...)
+	healthpb.RegisterHealthServer(server, srv)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	dialOpts = append(dialOpts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return healthpb.NewHealthClient(conn)
+}
+
+func initMemoryTracing(t *testing.T) {
+	err := ntrace.InitTracing(&nconf.Config{
+		App:   &nconf.AppConfig{Name: "foo-app"},
+		Trace: &nconf.TraceConfig{Enabled: true, Exporter: nconf.TraceExporterMemory},
+	})
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		assert.Nil(t, ntrace.Shutdown(context.Background()))
+	})
+}
+
+// newTraceContext - the context with the MDC of a new trace, as bound by MDCBindingUnaryServerInterceptor.
+func newTraceContext(t *testing.T) context.Context {
+	mdc := ncontext.NewMDC()
+	assert.Nil(t, ntrace.Extract(mdc, func(key string) string { return "" }))
+	return ncontext.WithMDC(context.Background(), mdc)
+}
+
+func spansOf(kind trace.SpanKind) []tracetest.SpanStub {
+	var spans []tracetest.SpanStub
+	for _, span := range ntrace.MemoryExporter().GetSpans() {
+		if span.SpanKind == kind {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+func TestTracingUnaryInterceptors(t *testing.T) {
+	a := assert.New(t)
+	initMemoryTracing(t)
+	srv := &testHealthServer{check: func(ctx context.Context, req *healthpb.HealthCheckRequest) error {
+		code, _ := strconv.Atoi(req.Service)
+		return status.Error(codes.Code(code), "check failed")
+	}}
+	client := newTestClient(t, srv,
+		[]grpc.ServerOption{grpc.ChainUnaryInterceptor(MDCBindingUnaryServerInterceptor, TracingUnaryServerInterceptor)},
+		grpc.WithChainUnaryInterceptor(TracingUnaryClientInterceptor, MDCBindingUnaryClientInterceptor))
+
+	tests := []struct {
+		code         codes.Code
+		serverStatus otelcodes.Code
+	}{
+		{codes.OK, otelcodes.Unset},
+		{codes.NotFound, otelcodes.Unset},
+		{codes.InvalidArgument, otelcodes.Unset},
+		{codes.PermissionDenied, otelcodes.Unset},
+		{codes.Unknown, otelcodes.Error},
+		{codes.DeadlineExceeded, otelcodes.Error},
+		{codes.Internal, otelcodes.Error},
+		{codes.Unavailable, otelcodes.Error},
+	}
+	for _, tt := range tests {
+		ntrace.MemoryExporter().Reset()
+		_, err := client.Check(newTraceContext(t), &healthpb.HealthCheckRequest{Service: strconv.Itoa(int(tt.code))})
+		a.Equal(tt.code, status.Code(err))
+
+		servers, clients := spansOf(trace.SpanKindServer), spansOf(trace.SpanKindClient)
+		a.Len(servers, 1)
+		a.Len(clients, 1)
+		server, client := servers[0], clients[0]
+		a.Equal("/grpc.health.v1.Health/Check", server.Name)
+		a.Equal(client.SpanContext.SpanID(), server.Parent.SpanID(), tt.code.String())
+		a.Equal(tt.serverStatus, server.Status.Code, tt.code.String())
+		a.Contains(server.Attributes, semconv.RPCGRPCStatusCodeKey.Int(int(tt.code)))
+		a.Contains(server.Attributes, semconv.RPCService("grpc.health.v1.Health"))
+		// the client span is error whatever the code is
+		clientStatus := otelcodes.Error
+		if tt.code == codes.OK {
+			clientStatus = otelcodes.Unset
+		}
+		a.Equal(clientStatus, client.Status.Code, tt.code.String())
+		a.Contains(client.Attributes, semconv.RPCGRPCStatusCodeKey.Int(int(tt.code)))
+	}
+}
+
+func TestTracingStreamInterceptors(t *testing.T) {
+	a := assert.New(t)
+	initMemoryTracing(t)
+	srv := &testHealthServer{watch: func(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+		if err := stream.Send(&healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}); err != nil {
+			return err
+		}
+		code, _ := strconv.Atoi(req.Service)
+		return status.Error(codes.Code(code), "watch failed")
+	}}
+	client := newTestClient(t, srv,
+		[]grpc.ServerOption{grpc.ChainStreamInterceptor(MDCBindingStreamServerInterceptor, TracingStreamServerInterceptor)},
+		grpc.WithChainStreamInterceptor(TracingStreamClientInterceptor, MDCBindingStreamClientInterceptor))
+
+	for _, code := range []codes.Code{codes.OK, codes.NotFound, codes.Unavailable} {
+		ntrace.MemoryExporter().Reset()
+		stream, err := client.Watch(newTraceContext(t), &healthpb.HealthCheckRequest{Service: strconv.Itoa(int(code))})
+		a.Nil(err)
+		_, err = stream.Recv()
+		a.Nil(err)
+		// the client span ends on the first error of RecvMsg only
+		a.Empty(spansOf(trace.SpanKindClient))
+
+		_, err = stream.Recv()
+		if code == codes.OK {
+			a.Equal(io.EOF, err)
+		} else {
+			a.Equal(code, status.Code(err))
+		}
+		_, err = stream.Recv()
+		a.NotNil(err)
+
+		servers, clients := spansOf(trace.SpanKindServer), spansOf(trace.SpanKindClient)
+		a.Len(servers, 1)
+		a.Len(clients, 1)
+		a.Equal("/grpc.health.v1.Health/Watch", clients[0].Name)
+		a.Equal(clients[0].SpanContext.SpanID(), servers[0].Parent.SpanID())
+		a.Contains(clients[0].Attributes, semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+		if code == codes.OK {
+			a.Equal(otelcodes.Unset, clients[0].Status.Code)
+			a.Equal(otelcodes.Unset, servers[0].Status.Code)
+		} else {
+			a.Equal(otelcodes.Error, clients[0].Status.Code)
+			a.Equal(code == codes.Unavailable, servers[0].Status.Code == otelcodes.Error, code.String())
+		}
+	}
+}
@@ -15,6 +15,7 @@
 package rpc
 
 import (
+	"github.com/nf-go/nfgo/nconf"
 	"github.com/nf-go/nfgo/nmetrics"
 	"github.com/nf-go/nfgo/rpc/interceptor"
 	"google.golang.org/grpc"
@@ -26,7 +27,7 @@ type serverOptions struct {
 	streamServerInterceptors []grpc.StreamServerInterceptor
 }
 
-func (opts *serverOptions) setInterceptors() {
+func (opts *serverOptions) setInterceptors(conf *nconf.RPCConfig) {
 	unaryInterceptors := []grpc.UnaryServerInterceptor{interceptor.RecoverUnaryServerInterceptor}
 	if opts.metricsServer != nil {
 		unaryInterceptors = append(unaryInterceptors, opts.metricsServer.GrpcMetricsUnaryServerInterceptor())
@@ -34,9 +35,11 @@ func (opts *serverOptions) setInterceptors() {
 	unaryInterceptors = append(unaryInterceptors,
 		interceptor.RecoverUnaryServerInterceptor,
 		interceptor.MDCBindingUnaryServerInterceptor,
+		interceptor.TracingUnaryServerInterceptor,
 		interceptor.ValidateUnaryServerInterceptor,
 		interceptor.LoggingUnaryServerInterceptor,
-		interceptor.ErrorHandleUnaryServerInterceptor)
+		interceptor.ErrorHandleUnaryServerInterceptor,
+		interceptor.DeadlineUnaryServerInterceptor(conf))
 	if len(opts.unaryServerInterceptors) == 0 {
 		opts.unaryServerInterceptors = unaryInterceptors
 	} else {
@@ -49,9 +52,11 @@ func (opts *serverOptions) setInterceptors() {
 	}
 	streamInterceptors = append(streamInterceptors,
 		interceptor.MDCBindingStreamServerInterceptor,
+		interceptor.TracingStreamServerInterceptor,
 		interceptor.ValidateStreamServerInterceptor,
 		interceptor.LoggingStreamServerInterceptor,
-		interceptor.ErrorHandleStreamServerInterceptor)
+		interceptor.ErrorHandleStreamServerInterceptor,
+		interceptor.DeadlineStreamServerInterceptor(conf))
 
 	if len(opts.streamServerInterceptors) == 0 {
 		opts.streamServerInterceptors = streamInterceptors
@@ -23,6 +23,7 @@ import (
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/nf-go/nfgo/nconf"
 	"github.com/nf-go/nfgo/nlog"
+	"github.com/nf-go/nfgo/ntrace"
 	"github.com/nf-go/nfgo/nutil/graceful"
 	"github.com/nf-go/nfgo/nutil/ntypes"
 
@@ -48,11 +49,12 @@ func NewServer(config *nconf.Config, opt ...ServerOption) (Server, error) {
 	if rpcConfig == nil {
 		return nil, errors.New("rpc config is not initialized in the config")
 	}
+	ntrace.InitPropagation(config)
 	opts := &serverOptions{}
 	for _, o := range opt {
 		o(opts)
 	}
-	opts.setInterceptors()
+	opts.setInterceptors(rpcConfig)
 
 	grpcOpts := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(int(rpcConfig.MaxRecvMsgSize)),
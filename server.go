@@ -26,6 +26,7 @@ import (
 	"github.com/nf-go/nfgo/nconf"
 	"github.com/nf-go/nfgo/nerrors"
 	"github.com/nf-go/nfgo/nlog"
+	"github.com/nf-go/nfgo/ntrace"
 	"github.com/nf-go/nfgo/nutil/graceful"
 	"go.uber.org/automaxprocs/maxprocs"
 )
@@ -103,6 +104,9 @@ func (s *nfgoServer) serve() {
 }
 
 func (s *nfgoServer) shutdown() {
+	// flushes the logs, especially the ones queued by the async logging
+	//nolint:errcheck
+	defer nlog.Sync()
 	nlog.Info("the server is going to shutdown...")
 	timeout := s.config.App.GraceTermination.GraceTerminationPeriod
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
@@ -131,5 +135,7 @@ func (s *nfgoServer) cleanup(ctx context.Context, cleaned chan<- error) {
 	for _, f := range s.onShutdown {
 		err = nerrors.Append(err, f())
 	}
+	// exports the ended spans
+	err = nerrors.Append(err, ntrace.Shutdown(ctx))
 	cleaned <- err
 }
@@ -15,7 +15,9 @@
 package web
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net"
 	"net/http"
@@ -52,7 +54,7 @@ func (c *Context) Success(data interface{}) {
 	respLogger := nlog.Logger(c)
 	if respLogger.IsLevelEnabled(nlog.DebugLevel) {
 		if respJSON, err := json.Marshal(r); err == nil {
-			respLogger.WithField("resp", string(respJSON)).Debug()
+			respLogger.WithField("resp", string(nlog.RedactJSON(respJSON))).Debug()
 		}
 	}
 
@@ -64,6 +66,11 @@ func (c *Context) Fail(err error) {
 	//nolint:errcheck // ignore errcheck!
 	c.Error(err)
 
+	// the work given up by the deadline, such as a db query, fails as the deadline biz error
+	if _, ok := err.(nerrors.BizError); !ok && errors.Is(err, context.DeadlineExceeded) {
+		err = nerrors.ErrDeadlineExceeded
+	}
+
 	// handle biz error
 	if bizErr, ok := err.(nerrors.BizError); ok {
 		var statusCode int
@@ -72,6 +79,8 @@ func (c *Context) Fail(err error) {
 			statusCode = http.StatusForbidden
 		case nerrors.ErrUnauthorized:
 			statusCode = http.StatusUnauthorized
+		case nerrors.ErrDeadlineExceeded:
+			statusCode = http.StatusGatewayTimeout
 		default:
 			statusCode = http.StatusOK
 		}
@@ -88,7 +97,8 @@ func (c *Context) Fail(err error) {
 		Code: nerrors.ErrInternal.Code(),
 		Msg:  nerrors.ErrInternal.Msg(),
 	})
-	nlog.Logger(c).WithError(err).Error()
+	// the stack of the handler is recorded if err has none, see log.errorStack
+	nlog.Logger(c).WithError(nerrors.WithStack(err)).Error()
 }
 
 // FormFileBytes - returns the first file bytes for the provided form key.
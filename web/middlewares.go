@@ -16,33 +16,34 @@ package web
 
 import (
 	"bytes"
+	"context"
 	"io"
+	"net/http"
 
+	"github.com/gin-gonic/gin"
 	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nerrors"
 	"github.com/nf-go/nfgo/nlog"
+	"github.com/nf-go/nfgo/ntrace"
 	"github.com/nf-go/nfgo/nutil/nconst"
-	"github.com/nf-go/nfgo/nutil/ncrypto"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // BindMDC - BindMDC MiddleWare
 func BindMDC() HandlerFunc {
 	return func(c *Context) {
 		mdc := ncontext.NewMDC()
-
-		traceID := c.GetHeader(nconst.HeaderTraceID)
-		if traceID == "" {
-			var err error
-			if traceID, err = ncrypto.UUID(); err != nil {
-				c.Fail(err)
-				c.Abort()
-				return
-			}
+		if err := ntrace.Extract(mdc, c.GetHeader); err != nil {
+			c.Fail(err)
+			c.Abort()
+			return
 		}
-		mdc.SetTraceID(traceID)
 		mdc.SetAPIName(c.Request.Method + " " + c.Request.URL.Path)
 		mdc.SetClientIP(c.ClientIP())
 		mdc.SetClientType(c.GetHeader(nconst.HeaderClientType))
 		mdc.SetSubjectID(c.GetHeader(nconst.HeaderSub))
+		ncontext.TraceOf(mdc).SetDebug(nlog.IsTraceDebugHeader(c.GetHeader(nconst.HeaderDebug)))
 
 		ctx := ncontext.WithMDC(c.Request.Context(), mdc)
 		c.Request = c.Request.WithContext(ctx)
@@ -50,20 +51,92 @@ func BindMDC() HandlerFunc {
 	}
 }
 
+// Tracing - starts the span of the request if the tracing is enabled, see ntrace.StartEntrySpan.
+// It should be after BindMDC.
+func Tracing() HandlerFunc {
+	return func(c *Context) {
+		if !ntrace.Enabled() {
+			c.Next()
+			return
+		}
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		ctx, span := ntrace.StartEntrySpan(c.Request.Context(), c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(c.Request.URL.Path),
+			))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		var err error
+		if len(c.Errors) > 0 {
+			err = c.Errors.Last().Err
+		} else if status >= http.StatusInternalServerError {
+			err = nerrors.New(http.StatusText(status))
+		}
+		ntrace.EndSpan(span, err)
+	}
+}
+
+// Deadline - applies the timeout of the route to the request, which is shortened by the budget in the X-Timeout
+// header, see web.timeout and web.routeTimeouts. The request fails fast with nerrors.ErrDeadlineExceeded
+// if the budget is exhausted.
+func Deadline() HandlerFunc {
+	return func(c *Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		timeout := c.webConfig.RouteTimeout(c.Request.Method, route)
+		if budget, ok := ncontext.ParseBudget(c.GetHeader(nconst.HeaderTimeout)); ok {
+			if budget <= 0 {
+				c.Fail(nerrors.ErrDeadlineExceeded)
+				c.Abort()
+				return
+			}
+			if timeout <= 0 || budget < timeout {
+				timeout = budget
+			}
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
 // Logging -
 func Logging() HandlerFunc {
 	return func(c *Context) {
 		if c.webConfig.IsSensitiveURLPath(c.Request.URL.Path) {
 			nlog.Logger(c).WithField("req", "sensitive ******").Info()
 		} else if c.IsMultipartReq() {
-			nlog.Logger(c).WithField("req", c.Request.URL.RawQuery).Info()
+			nlog.Logger(c).WithField("req", nlog.RedactQuery(c.Request.URL.RawQuery)).Info()
 		} else {
 			var buf bytes.Buffer
 			teeReader := io.TeeReader(c.Request.Body, &buf)
 			body, _ := io.ReadAll(teeReader)
 			c.Request.Body = io.NopCloser(&buf)
-			nlog.Logger(c).WithField("req", c.Request.URL.RawQuery+" "+string(body)).Info()
+			nlog.Logger(c).WithField("req", nlog.RedactQuery(c.Request.URL.RawQuery)+" "+redactBody(c.ContentType(), body)).Info()
 		}
 		c.Next()
 	}
 }
+
+func redactBody(contentType string, body []byte) string {
+	if contentType == gin.MIMEPOSTForm {
+		return nlog.RedactQuery(string(body))
+	}
+	return string(nlog.RedactJSON(body))
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 The nfgo Authors. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nf-go/nfgo/nconf"
+	"github.com/nf-go/nfgo/ncontext"
+	"github.com/nf-go/nfgo/nerrors"
+	"github.com/nf-go/nfgo/ntrace"
+	"github.com/nf-go/nfgo/nutil/nconst"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// newTestEngine - the engine with the middlewares of the web server, the handler serves GET /users/:id.
+func newTestEngine(conf *nconf.WebConfig, handler HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	(&serverOptions{}).setMiddlewaresToEngine(engine, conf)
+	engine.GET("/users/:id", handler.WrapHandler(conf))
+	return engine
+}
+
+func serve(engine *gin.Engine, path string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w
+}
+
+func initMemoryTracing(t *testing.T) {
+	err := ntrace.InitTracing(&nconf.Config{
+		App:   &nconf.AppConfig{Name: "foo-app"},
+		Trace: &nconf.TraceConfig{Enabled: true, Exporter: nconf.TraceExporterMemory},
+	})
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		assert.Nil(t, ntrace.Shutdown(context.Background()))
+	})
+}
+
+func TestTracing(t *testing.T) {
+	a := assert.New(t)
+	initMemoryTracing(t)
+	engine := newTestEngine(&nconf.WebConfig{}, func(c *Context) {
+		switch c.Param("id") {
+		case "forbidden":
+			c.Fail(nerrors.ErrForbidden)
+		case "crash":
+			c.Fail(nerrors.New("connection refused"))
+		default:
+			c.Success(c.Param("id"))
+		}
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		code   codes.Code
+	}{
+		{"/users/1", http.StatusOK, codes.Unset},
+		{"/users/forbidden", http.StatusForbidden, codes.Unset},
+		{"/users/crash", http.StatusInternalServerError, codes.Error},
+	}
+	for _, tt := range tests {
+		ntrace.MemoryExporter().Reset()
+		w := serve(engine, tt.path, map[string]string{
+			nconst.HeaderTraceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		})
+		a.Equal(tt.status, w.Code)
+
+		spans := ntrace.MemoryExporter().GetSpans()
+		a.Len(spans, 1)
+		span := spans[0]
+		a.Equal("GET /users/:id", span.Name)
+		a.Equal("4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext.TraceID().String())
+		a.Equal("00f067aa0ba902b7", span.Parent.SpanID().String())
+		a.Equal(tt.code, span.Status.Code, tt.path)
+		a.Contains(span.Attributes, semconv.HTTPRoute("/users/:id"))
+		a.Contains(span.Attributes, semconv.URLPath(tt.path))
+		a.Contains(span.Attributes, semconv.HTTPResponseStatusCode(tt.status))
+	}
+
+	// the path is the name of the span if no route matches
+	ntrace.MemoryExporter().Reset()
+	a.Equal(http.StatusNotFound, serve(engine, "/orders/1", nil).Code)
+	spans := ntrace.MemoryExporter().GetSpans()
+	a.Len(spans, 1)
+	a.Equal("GET /orders/1", spans[0].Name)
+	a.False(spans[0].Parent.IsValid())
+}
+
+func TestDeadline(t *testing.T) {
+	a := assert.New(t)
+	conf := &nconf.WebConfig{
+		Timeout:       time.Minute,
+		RouteTimeouts: map[string]time.Duration{"GET /users/:id": 30 * time.Second},
+	}
+	var budget time.Duration
+	var hasDeadline, called bool
+	handler := func(c *Context) {
+		called = true
+		budget, hasDeadline = ncontext.Budget(c.Request.Context())
+		if c.Param("id") == "slow" {
+			<-c.Request.Context().Done()
+			c.Fail(c.Request.Context().Err())
+			return
+		}
+		c.Success(c.Param("id"))
+	}
+	engine := newTestEngine(conf, handler)
+
+	// the timeout of the route
+	a.Equal(http.StatusOK, serve(engine, "/users/1", nil).Code)
+	a.True(hasDeadline)
+	a.True(budget > 20*time.Second && budget <= 30*time.Second, budget)
+
+	// the budget in X-Timeout is shorter than the timeout of the route
+	a.Equal(http.StatusOK, serve(engine, "/users/1", map[string]string{nconst.HeaderTimeout: "2000"}).Code)
+	a.True(budget > 0 && budget <= 2*time.Second, budget)
+
+	// the timeout of the route is shorter than the budget
+	a.Equal(http.StatusOK, serve(engine, "/users/1", map[string]string{nconst.HeaderTimeout: "3600000"}).Code)
+	a.True(budget > 20*time.Second && budget <= 30*time.Second, budget)
+
+	// the exhausted budget fails fast
+	called = false
+	w := serve(engine, "/users/1", map[string]string{nconst.HeaderTimeout: "0"})
+	a.Equal(http.StatusGatewayTimeout, w.Code)
+	a.JSONEq(`{"code":-4,"msg":"deadline exceeded"}`, w.Body.String())
+	a.False(called)
+
+	// the work given up by the deadline fails as the deadline biz error
+	w = serve(engine, "/users/slow", map[string]string{nconst.HeaderTimeout: "10"})
+	a.Equal(http.StatusGatewayTimeout, w.Code)
+
+	// no deadline without the timeout and the budget
+	engine = newTestEngine(&nconf.WebConfig{}, handler)
+	a.Equal(http.StatusOK, serve(engine, "/users/1", nil).Code)
+	a.False(hasDeadline)
+}
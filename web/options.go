@@ -30,7 +30,8 @@ func (opts *serverOptions) setMiddlewaresToEngine(engine *gin.Engine, conf *ncon
 	if opts.metricsServer != nil {
 		middleWares = append(middleWares, opts.metricsServer.WebMetricsMiddleware())
 	}
-	middleWares = append(middleWares, BindMDC().WrapHandler(conf), Logging().WrapHandler(conf))
+	middleWares = append(middleWares, BindMDC().WrapHandler(conf), Tracing().WrapHandler(conf), Deadline().WrapHandler(conf),
+		Logging().WrapHandler(conf))
 	if len(opts.middlewares) > 0 {
 		for _, m := range opts.middlewares {
 			middleWares = append(middleWares, m.WrapHandler(conf))
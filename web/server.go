@@ -25,6 +25,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/nf-go/nfgo/nconf"
 	"github.com/nf-go/nfgo/nlog"
+	"github.com/nf-go/nfgo/ntrace"
 	"github.com/nf-go/nfgo/nutil/graceful"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -110,6 +111,7 @@ func NewServer(config *nconf.Config, opt ...ServerOption) (Server, error) {
 	if webConfig == nil {
 		return nil, errors.New("web config is not initialized in the config")
 	}
+	ntrace.InitPropagation(config)
 
 	// gin engine
 	engine := gin.New()